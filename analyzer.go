@@ -5,7 +5,6 @@ import (
 	"go/ast"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
-	"strings"
 )
 
 // AnalyzerPlugin is the entry point for the linter.
@@ -17,7 +16,12 @@ type AnalyzerPlugin struct {
 	Settings Settings
 }
 
+// BuildAnalyzers compiles the package patterns of targets that were not decoded from a configuration, so that each
+// pattern is only compiled once, and returns the analyzer of the linter.
 func (a *AnalyzerPlugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	if err := a.Settings.compilePatterns(); err != nil {
+		return nil, err
+	}
 	return []*analysis.Analyzer{
 		{
 			Name:     "qawaylinter",
@@ -30,54 +34,87 @@ func (a *AnalyzerPlugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
 
 // Run executes the analysis step of the linter.
 // The method iterates over all files and applies all rules to the nodes in the file.
-// Please refer to the Rule interface for more information on how to implement rules.
-// Do to limitations in Go generics, the rules are split, e.g. into FunctionRules and InterfaceRules.
-// It would be better if they would all be part of a single list as they all implement the same interface,
-// but this was not possible.
+// Please refer to the Rule and Checker interfaces for more information on how to implement rules.
+// All rules of the matching target are executed in a single list, see RegisterRule for adding new rule kinds.
+// Afterwards, rules implementing PackageChecker are executed once for checks of the package as a whole.
 func (a *AnalyzerPlugin) Run(pass *analysis.Pass) (interface{}, error) {
+	target, err := a.Settings.GetMatchingTarget(pass.Pkg)
+	if target == nil || err != nil {
+		return nil, err
+	}
+	// diagnostics are filtered before they are reported to support rule-scoped nolint directives.
+	directives := collectNolintDirectives(pass)
+	report := pass.Report
+	filteredPass := *pass
+	filteredPass.Report = func(diagnostic analysis.Diagnostic) {
+		if !directives.Suppresses(pass.Fset, diagnostic) {
+			report(diagnostic)
+		}
+	}
+	pass = &filteredPass
+
 	var file *ast.File
+	var checks []Checker
+	var testFile bool
 	inspect := func(node ast.Node) bool {
 		if node == nil {
 			return true
 		}
 
-		target := a.Settings.GetMatchingTarget(pass.Pkg)
-		if target == nil {
-			return true
-		}
-
-		if target.FunctionRule != nil && target.FunctionRule.IsApplicable(node, pass, file) {
-			results := target.FunctionRule.Analyse(node, pass, file)
-			target.FunctionRule.Apply(results, node, pass)
-		}
-
-		if target.InterfaceRule != nil && target.InterfaceRule.IsApplicable(node, pass, file) {
-			results := target.InterfaceRule.Analyse(node, pass, file)
-			target.InterfaceRule.Apply(results, node, pass)
+		if funcDecl, ok := node.(*ast.FuncDecl); ok && testFile && isTestEntryPoint(funcDecl) {
+			return false
 		}
 
-		if target.StructRule != nil && target.StructRule.IsApplicable(node, pass, file) {
-			results := target.StructRule.Analyse(node, pass, file)
-			target.StructRule.Apply(results, node, pass)
+		for _, check := range checks {
+			check.Check(node, pass, file)
 		}
 
 		return true
 
 	}
 
+	var packageFiles []*ast.File
 	for _, f := range pass.Files {
 		filename := pass.Fset.Position(f.Pos()).Filename
 
-		// skip all tests fails as documenting them is not as important
-		if strings.HasSuffix(filename, "_test.go") {
+		// generated code is not maintained manually and therefore does not need to be documented
+		if ast.IsGenerated(f) || !target.MatchesFile(filename) {
 			continue
 		}
+
+		// test files are only linted if the target contains separate rules for them
+		testFile = isTestFile(filename)
+		checks = target.OrderedChecks()
+		if testFile {
+			if target.Tests == nil {
+				continue
+			}
+			checks = target.Tests.OrderedChecks()
+		}
+
 		file = f
 		ast.Inspect(f, inspect)
+		if !testFile {
+			packageFiles = append(packageFiles, f)
+		}
+	}
+
+	// package-level rules are executed once after all files have been inspected.
+	if len(packageFiles) > 0 {
+		for _, check := range target.OrderedChecks() {
+			if packageCheck, ok := check.(PackageChecker); ok {
+				packageCheck.CheckPackage(pass, packageFiles)
+			}
+		}
 	}
 	return nil, nil
 }
 
+// GetLoadMode requests type information from golangci-lint only if a configured rule requires it,
+// see TypesInfoRequirer.
 func (a *AnalyzerPlugin) GetLoadMode() string {
+	if a.Settings.RequiresTypesInfo() {
+		return register.LoadModeTypesInfo
+	}
 	return register.LoadModeSyntax
 }
@@ -0,0 +1,60 @@
+// Command qawaylinter runs the QAway linter without golangci-lint.
+//
+// The settings are read from the file given by the -config flag. If the flag is not set, the command searches for a
+// .qaway.yml, .qaway.yaml or .qaway.json file in the current directory and its parents.
+//
+// Usage:
+//
+//	qawaylinter [-config .qaway.yml] ./...
+//
+// The command can also be used as a vet tool:
+//
+//	go vet -vettool=$(which qawaylinter) ./...
+package main
+
+import (
+	qawaylinter "github.com/qaware/qaway-linter"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/singlechecker"
+	"log"
+	"sync"
+)
+
+func main() {
+	plugin := &qawaylinter.AnalyzerPlugin{}
+	analyzers, err := plugin.BuildAnalyzers()
+	if err != nil {
+		log.Fatal(err)
+	}
+	analyzer := analyzers[0]
+
+	var configPath string
+	analyzer.Flags.StringVar(&configPath, "config", "", "path to the configuration file (default: search for .qaway.yml)")
+
+	// the flags are parsed by singlechecker.Main, so the settings can only be loaded once the analysis is running.
+	var once sync.Once
+	var loadErr error
+	run := analyzer.Run
+	analyzer.Run = func(pass *analysis.Pass) (interface{}, error) {
+		once.Do(func() {
+			plugin.Settings, loadErr = loadSettings(configPath)
+		})
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return run(pass)
+	}
+
+	singlechecker.Main(analyzer)
+}
+
+func loadSettings(path string) (qawaylinter.Settings, error) {
+	if path == "" {
+		var err error
+		path, err = qawaylinter.FindConfigFile(".")
+		if err != nil {
+			return qawaylinter.Settings{}, err
+		}
+	}
+	return qawaylinter.LoadSettings(path)
+}
@@ -0,0 +1,120 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// directivePattern matches directive comments as defined by go/ast, e.g. `//go:noinline` or `//nolint:errcheck`.
+// Nolint directives in all forms, e.g. `//nolint` or `// nolint:errcheck`, are recognized by isNolintDirective.
+var directivePattern = regexp.MustCompile(`^//(line |extern |export |[a-z0-9]+:[a-z0-9])`)
+
+// buildConstraintPattern matches build constraints in the legacy syntax, e.g. `// +build linux`.
+var buildConstraintPattern = regexp.MustCompile(`^//\s*\+build\b`)
+
+// licensePattern matches the first line of a license header, e.g. `Copyright 2024 QAware GmbH`.
+var licensePattern = regexp.MustCompile(`(?i)^(copyright\b|\(c\)|spdx-license-identifier:|licensed under\b)`)
+
+// notePattern matches the names of notes such as `TODO(alice)` or `BUG(bob)`, which parse as calls.
+var notePattern = regexp.MustCompile(`^[A-Z]+$`)
+
+// countCommentLines counts the lines of documentation in a comment group.
+// Lines that do not document the code are not counted: directives such as `//go:generate` or `//nolint:errcheck`,
+// build constraints, blank lines, lines starting with one of the ignored prefixes and license headers, which extend
+// from a line such as `// Copyright ...` to the next blank line.
+// A comment spanning multiple lines using the /* */ syntax counts with each of its non-blank lines.
+func countCommentLines(group *ast.CommentGroup, ignorePrefixes []string) int {
+	if group == nil {
+		return 0
+	}
+
+	lines := 0
+	license := false
+	for _, comment := range group.List {
+		if directivePattern.MatchString(comment.Text) || isNolintDirective(comment.Text) || buildConstraintPattern.MatchString(comment.Text) {
+			continue
+		}
+		for _, line := range commentTextLines(comment.Text) {
+			switch {
+			case line == "":
+				license = false
+			case license:
+			case licensePattern.MatchString(line):
+				license = true
+			case hasAnyPrefix(line, ignorePrefixes):
+			default:
+				lines++
+			}
+		}
+	}
+	return lines
+}
+
+// commentTextLines returns the lines of a comment without comment markers and surrounding whitespace.
+func commentTextLines(text string) []string {
+	if content, ok := strings.CutPrefix(text, "//"); ok {
+		return []string{strings.TrimSpace(content)}
+	}
+
+	content := strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		// leading asterisks are a common style for multi-line block comments.
+		lines[i] = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+	}
+	return lines
+}
+
+// hasAnyPrefix checks if the text starts with one of the prefixes.
+func hasAnyPrefix(text string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommentedOutCode determines if the text of a comment group is Go code, i.e. it parses as declarations or as
+// statements of which at least one is clearly code: an assignment, a call, a block or a statement with a block such as
+// `if` or `for`. Prose that happens to parse, e.g. `// TODO: document`, `// return early`, `// continue` or
+// `// TODO(alice)`, is not considered code.
+func isCommentedOutCode(group *ast.CommentGroup) bool {
+	text := strings.TrimSpace(group.Text())
+	if text == "" {
+		return false
+	}
+
+	if f, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+text, parser.SkipObjectResolution); err == nil {
+		return len(f.Decls) > 0
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package p\nfunc _() {\n"+text+"\n}", parser.SkipObjectResolution)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(f.Decls[0].(*ast.FuncDecl).Body.List, isCodeStmt)
+}
+
+// isCodeStmt checks if a statement is clearly code and not prose that happens to parse as a statement.
+// Return, branch and expression statements other than calls are not considered code, e.g. `return early` or `continue`.
+func isCodeStmt(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.LabeledStmt:
+		return isCodeStmt(s.Stmt)
+	case *ast.ExprStmt:
+		call, ok := s.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		ident, ok := call.Fun.(*ast.Ident)
+		return !ok || !notePattern.MatchString(ident.Name)
+	case *ast.AssignStmt, *ast.IncDecStmt, *ast.SendStmt, *ast.DeclStmt, *ast.GoStmt, *ast.DeferStmt, *ast.BlockStmt,
+		*ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+		return true
+	}
+	return false
+}
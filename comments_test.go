@@ -0,0 +1,132 @@
+package qawaylinter
+
+import (
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCountCommentLines(t *testing.T) {
+	tests := []struct {
+		name           string
+		comment        string
+		ignorePrefixes []string
+		expected       int
+	}{
+		{name: "Line comments", comment: "// Foo does things.\n// It is fast.", expected: 2},
+		{name: "Block comment", comment: "/*\n * Foo does things.\n *\n * It is fast.\n */", expected: 2},
+		{name: "Directive", comment: "//go:noinline", expected: 0},
+		{name: "Nolint directive", comment: "// Foo does things.\n//\n//nolint:errcheck", expected: 1},
+		{name: "Bare nolint directive", comment: "// Foo does things.\n//nolint", expected: 1},
+		{name: "Spaced nolint directive", comment: "// Foo does things.\n// nolint:qawaylinter", expected: 1},
+		{name: "Spaced bare nolint directive", comment: "// Foo does things.\n// nolint", expected: 1},
+		{name: "Nolint in prose", comment: "// Foo does things.\n// nolintable code is rare.", expected: 2},
+		{name: "Generate directive", comment: "//go:generate stringer -type=Foo", expected: 0},
+		{name: "Build constraint", comment: "// +build linux", expected: 0},
+		{name: "Blank lines", comment: "// Foo does things.\n//\n//\n// It is fast.", expected: 2},
+		{name: "Ignored prefix", comment: "// TODO: document\n// Foo does things.", ignorePrefixes: []string{"TODO"}, expected: 1},
+		{name: "License header", comment: "// Copyright 2024 QAware GmbH\n// Licensed under the MIT license.\n//\n// Package foo does things.", expected: 1},
+		{name: "SPDX identifier", comment: "// SPDX-License-Identifier: MIT", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parser.ParseFile(token.NewFileSet(), "foo.go", tt.comment+"\npackage foo\n", parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse source: %s", err)
+			}
+			if actual := countCommentLines(f.Comments[0], tt.ignorePrefixes); actual != tt.expected {
+				t.Errorf("countCommentLines() = %d; want %d", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDirectivesAreNoHeadlineComments(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"directives"},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{RequireHeadlineComment: true, IgnoreCommentPrefixes: []string{"TODO"}},
+					}),
+					"types": NewChecker[TypeRuleResults](TypeRule[TypeRuleResults]{
+						Params: TypeRuleParameters{RequireHeadlineComment: true},
+					}),
+				},
+			},
+		},
+	}}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "directives")
+}
+
+func TestIsCommentedOutCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		comment  string
+		expected bool
+	}{
+		{name: "Prose", comment: "// Foo does things.", expected: false},
+		{name: "Identifier", comment: "// TODO", expected: false},
+		{name: "Labeled identifier", comment: "// TODO: document", expected: false},
+		{name: "Statements", comment: "// x := 1\n// fmt.Println(x)", expected: true},
+		{name: "Block", comment: "/*\nif err != nil {\n\treturn err\n}\n*/", expected: true},
+		{name: "Declaration", comment: "// func old() {}", expected: true},
+		{name: "Call", comment: "// cleanup()", expected: true},
+		{name: "Return", comment: "// return early", expected: false},
+		{name: "Branch", comment: "// continue", expected: false},
+		{name: "Note", comment: "// TODO(alice)", expected: false},
+		{name: "Note with statement", comment: "// TODO(alice)\n// x++", expected: true},
+		{name: "Return with assignment", comment: "// err := cleanup()\n// return err", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parser.ParseFile(token.NewFileSet(), "foo.go", "package foo\n\n"+tt.comment+"\n", parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse source: %s", err)
+			}
+			if actual := isCommentedOutCode(f.Comments[0]); actual != tt.expected {
+				t.Errorf("isCommentedOutCode() = %t; want %t", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommentedOutCode(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"deadcode"},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{
+							MinCommentDensity:        0.5,
+							MinCommentedOutCodeLines: 1,
+							IgnoreCommentPrefixes:    []string{"want `"},
+						},
+					}),
+				},
+			},
+		},
+	}}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "deadcode")
+}
@@ -0,0 +1,289 @@
+package qawaylinter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConfigFileNames are the names of the configuration files that are searched by FindConfigFile, in order of preference.
+var ConfigFileNames = []string{".qaway.yml", ".qaway.yaml", ".qaway.json"}
+
+// LoadSettings reads the settings from a standalone configuration file.
+// The file contains the same content as the `settings` block of the golangci-lint configuration.
+// As JSON is a subset of YAML, both formats are supported.
+func LoadSettings(path string) (Settings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return Settings{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	settings, err := DecodeSettings(raw)
+	if err != nil {
+		return Settings{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// FindConfigFile searches the given directory and all of its parents for a configuration file.
+// It returns an error if no configuration file was found.
+func FindConfigFile(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range ConfigFileNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("no configuration file found, expected one of .qaway.yml, .qaway.yaml or .qaway.json")
+		}
+		dir = parent
+	}
+}
+
+// ConfigError describes an invalid entry in the configuration.
+type ConfigError struct {
+	// Path of the invalid entry, e.g. `rules[2].functions.params.minCommentDensity`.
+	Path string
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// prefixConfigError prepends the given path segment to the path of the error.
+// Errors that are not a ConfigError are wrapped into one.
+func prefixConfigError(prefix string, err error) error {
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		return &ConfigError{Path: prefix, Err: err}
+	}
+	path := configErr.Path
+	switch {
+	case path == "":
+		path = prefix
+	case !strings.HasPrefix(path, "["):
+		path = prefix + "." + path
+	default:
+		path = prefix + path
+	}
+	return &ConfigError{Path: path, Err: configErr.Err}
+}
+
+// DecodeSettings decodes the settings as passed by golangci-lint.
+// In contrast to a plain JSON decoding, unknown keys are rejected on all levels and parameters are range-checked.
+// Errors are returned as ConfigError with the path to the offending entry.
+func DecodeSettings(conf any) (Settings, error) {
+	data, err := json.Marshal(conf)
+	if err != nil {
+		return Settings{}, fmt.Errorf("encoding settings: %w", err)
+	}
+
+	var settings Settings
+	if err := DecodeStrict(data, &settings); err != nil {
+		return Settings{}, err
+	}
+	return settings, nil
+}
+
+// DecodeStrict decodes JSON into the value pointed to by target.
+// Keys that do not correspond to a field of the target are rejected.
+// Numeric fields are checked against the `minimum` and `maximum` struct tags, e.g. `minimum:"0" maximum:"1"`.
+// Structs can implement `Validate() error` for further validation.
+// Types implementing json.Unmarshaler are decoded by their own implementation.
+func DecodeStrict(data []byte, target any) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("target must be a non-nil pointer, got %T", target)
+	}
+	return decodeValue(data, value.Elem())
+}
+
+func decodeValue(data []byte, value reflect.Value) error {
+	if unmarshaler, ok := value.Addr().Interface().(json.Unmarshaler); ok {
+		return unmarshaler.UnmarshalJSON(data)
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if string(data) == "null" {
+			value.SetZero()
+			return nil
+		}
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return decodeValue(data, value.Elem())
+	case reflect.Struct:
+		return decodeStruct(data, value)
+	case reflect.Slice:
+		if !isComposite(value.Type().Elem()) {
+			return decodeJSON(data, value)
+		}
+		var elements []json.RawMessage
+		if err := decodeJSON(data, reflect.ValueOf(&elements).Elem()); err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(value.Type(), len(elements), len(elements))
+		for i, element := range elements {
+			if err := decodeValue(element, slice.Index(i)); err != nil {
+				return prefixConfigError("["+strconv.Itoa(i)+"]", err)
+			}
+		}
+		value.Set(slice)
+		return nil
+	default:
+		return decodeJSON(data, value)
+	}
+}
+
+func decodeStruct(data []byte, value reflect.Value) error {
+	var entries map[string]json.RawMessage
+	if err := decodeJSON(data, reflect.ValueOf(&entries).Elem()); err != nil {
+		return err
+	}
+
+	fields := jsonFields(value.Type())
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		field, ok := fields[key]
+		if !ok {
+			return &ConfigError{Path: key, Err: errors.New("unknown key")}
+		}
+		fieldValue := value.FieldByIndex(field.Index)
+		if err := decodeValue(entries[key], fieldValue); err != nil {
+			return prefixConfigError(key, err)
+		}
+		if err := checkRange(fieldValue, field); err != nil {
+			return &ConfigError{Path: key, Err: err}
+		}
+	}
+
+	if validator, ok := value.Addr().Interface().(validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// validator is implemented by configuration structs that require validation beyond range checks.
+// Validate is called by DecodeStrict after all fields of the struct have been decoded.
+// The returned error may be a ConfigError with a path relative to the struct.
+type validator interface {
+	Validate() error
+}
+
+// decodeJSON decodes plain JSON values and converts type errors into readable messages.
+func decodeJSON(data []byte, value reflect.Value) error {
+	if err := json.Unmarshal(data, value.Addr().Interface()); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return &ConfigError{Err: fmt.Errorf("expected %s, got %s", jsonTypeName(value.Type()), typeErr.Value)}
+		}
+		return &ConfigError{Err: err}
+	}
+	return nil
+}
+
+// checkRange validates numeric fields against their `minimum` and `maximum` tags.
+func checkRange(value reflect.Value, field reflect.StructField) error {
+	var number float64
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		number = value.Float()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(value.Int())
+	default:
+		return nil
+	}
+
+	if minimum, ok := field.Tag.Lookup("minimum"); ok {
+		if limit, _ := strconv.ParseFloat(minimum, 64); number < limit {
+			return fmt.Errorf("must be at least %s, got %v", minimum, number)
+		}
+	}
+	if maximum, ok := field.Tag.Lookup("maximum"); ok {
+		if limit, _ := strconv.ParseFloat(maximum, 64); number > limit {
+			return fmt.Errorf("must be at most %s, got %v", maximum, number)
+		}
+	}
+	return nil
+}
+
+// jsonFields returns the exported fields of a struct by their JSON key.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// isComposite determines if values of the type are decoded field by field to provide detailed error paths.
+func isComposite(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct || reflect.PointerTo(t).Implements(reflect.TypeFor[json.Unmarshaler]())
+}
+
+// jsonTypeName returns the name of the JSON type that corresponds to the Go type.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
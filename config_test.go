@@ -0,0 +1,164 @@
+package qawaylinter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+	}{
+		{
+			name:     "YAML",
+			filename: ".qaway.yml",
+			content: `
+rules:
+  - packages: [ "example.com/foo" ]
+    functions:
+      params:
+        requireHeadlineComment: true
+`,
+		},
+		{
+			name:     "JSON",
+			filename: ".qaway.json",
+			content:  `{"rules": [{"packages": ["example.com/foo"], "functions": {"params": {"requireHeadlineComment": true}}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.filename)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %s", err)
+			}
+
+			settings, err := LoadSettings(path)
+			if err != nil {
+				t.Fatalf("Failed to load settings: %s", err)
+			}
+			if len(settings.Targets) != 1 || settings.Targets[0].Packages[0] != "example.com/foo" {
+				t.Fatalf("Expected a single target for example.com/foo, but got %v", settings.Targets)
+			}
+			if _, ok := settings.Targets[0].Checks["functions"]; !ok {
+				t.Errorf("Expected functions rule to be configured, but got %v", settings.Targets[0].Checks)
+			}
+		})
+	}
+}
+
+func TestFindConfigFile(t *testing.T) {
+	root := t.TempDir()
+	subdir := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(subdir, 0o755); err != nil {
+		t.Fatalf("Failed to create directories: %s", err)
+	}
+	expected := filepath.Join(root, ".qaway.yml")
+	if err := os.WriteFile(expected, []byte("rules: []"), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %s", err)
+	}
+
+	path, err := FindConfigFile(subdir)
+	if err != nil {
+		t.Fatalf("Failed to find config file: %s", err)
+	}
+	if path != expected {
+		t.Errorf("Expected config file %s, but got %s", expected, path)
+	}
+}
+
+func TestDecodeSettingsErrors(t *testing.T) {
+	target := func(rule string, config map[string]any) map[string]any {
+		return map[string]any{"packages": []any{"example.com/foo"}, rule: config}
+	}
+	tests := []struct {
+		name     string
+		rules    []any
+		expected string
+	}{
+		{
+			name:     "Unknown parameter",
+			rules:    []any{target("functions", map[string]any{"params": map[string]any{"requireHeadlineComments": true}})},
+			expected: "rules[0].functions.params.requireHeadlineComments: unknown key",
+		},
+		{
+			name: "Value out of range",
+			rules: []any{
+				target("interfaces", map[string]any{}),
+				target("structs", map[string]any{}),
+				target("functions", map[string]any{"params": map[string]any{"minCommentDensity": 7}}),
+			},
+			expected: "rules[2].functions.params.minCommentDensity: must be at most 1, got 7",
+		},
+		{
+			name:     "Negative filter",
+			rules:    []any{target("functions", map[string]any{"filters": map[string]any{"minLinesOfCode": -1}})},
+			expected: "rules[0].functions.filters.minLinesOfCode: must be at least 0, got -1",
+		},
+		{
+			name:     "Wrong type",
+			rules:    []any{target("structs", map[string]any{"params": map[string]any{"requireFieldComment": "yes"}})},
+			expected: "rules[0].structs.params.requireFieldComment: expected boolean, got string",
+		},
+		{
+			name:     "Invalid name pattern",
+			rules:    []any{target("interfaces", map[string]any{"filters": map[string]any{"includeNames": "Reader("}})},
+			expected: "rules[0].interfaces.filters.includeNames: invalid regular expression: error parsing regexp: missing closing ): `Reader(`",
+		},
+		{
+			name:     "Conflicting filters",
+			rules:    []any{target("functions", map[string]any{"filters": map[string]any{"functionsOnly": true, "methodsOnly": true}})},
+			expected: "rules[0].functions.filters: functionsOnly and methodsOnly are mutually exclusive",
+		},
+		{
+			name:     "Conflicting name filters",
+			rules:    []any{target("structs", map[string]any{"filters": map[string]any{"exportedOnly": true, "unexportedOnly": true}})},
+			expected: "rules[0].structs.filters: exportedOnly and unexportedOnly are mutually exclusive",
+		},
+		{
+			name:     "Unknown type kind",
+			rules:    []any{target("types", map[string]any{"filters": map[string]any{"kinds": []any{"func", "struct"}}})},
+			expected: "rules[0].types.filters.kinds[1]: unknown kind \"struct\", expected one of alias, array, chan, func, map, named, pointer, slice",
+		},
+		{
+			name:     "Unknown message case",
+			rules:    []any{target("logMessages", map[string]any{"params": map[string]any{"messageCase": "title"}})},
+			expected: "rules[0].logMessages.params.messageCase: unknown case \"title\", expected one of lower, upper",
+		},
+		{
+			name:     "Unknown logging preset",
+			rules:    []any{target("functions", map[string]any{"params": map[string]any{"logging": map[string]any{"presets": []any{"log", "glog"}}}})},
+			expected: "rules[0].functions.params.logging.presets[1]: unknown preset \"glog\", expected one of log, logrus, slog, zap, zerolog",
+		},
+		{
+			name:     "Unknown similarity metric",
+			rules:    []any{target("functions", map[string]any{"params": map[string]any{"similarityMetric": "cosine"}})},
+			expected: "rules[0].functions.params.similarityMetric: unknown metric \"cosine\", expected one of levenshtein, jaccard, jaroWinkler",
+		},
+		{
+			name:     "Unknown rule",
+			rules:    []any{target("function", map[string]any{})},
+			expected: "rules[0].function: unknown rule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeSettings(map[string]any{"rules": tt.rules})
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("DecodeSettings() error = %v; want %s", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDecodeSettingsUnknownTopLevelKey(t *testing.T) {
+	_, err := DecodeSettings(map[string]any{"rule": []any{}})
+	if err == nil || err.Error() != "rule: unknown key" {
+		t.Errorf("DecodeSettings() error = %v; want rule: unknown key", err)
+	}
+}
@@ -0,0 +1,87 @@
+package qawaylinter
+
+import (
+	"bytes"
+	"go/token"
+	"golang.org/x/tools/go/analysis"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// defaultCommentTemplate is used for suggested fixes if a rule does not configure a `commentTemplate`.
+// It results in a godoc-conformant stub such as `// GetValue ...`.
+const defaultCommentTemplate = "{{.Name}} ..."
+
+// CommentTemplateData is passed to the `commentTemplate` of a rule when a doc comment stub is generated.
+type CommentTemplateData struct {
+	// Name of the documented symbol, e.g. the name of a function or a field.
+	Name string
+	// Kind of the documented symbol: function, method, interface, interface method, struct, field, embedded field, type,
+	// constant or variable.
+	Kind string
+}
+
+// parseCommentTemplate parses the template of a doc comment stub, using the default template if none is configured.
+func parseCommentTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = defaultCommentTemplate
+	}
+	return template.New("comment").Parse(text)
+}
+
+// suggestDocComment creates a suggested fix that inserts a doc comment stub in front of the symbol starting at pos.
+// Each line of the rendered template is turned into a line comment with the same indentation as the symbol.
+// No fix is returned if the template cannot be rendered or if the symbol does not start its own line.
+func suggestDocComment(pass *analysis.Pass, pos token.Pos, commentTemplate string, data CommentTemplateData) []analysis.SuggestedFix {
+	tmpl, err := parseCommentTemplate(commentTemplate)
+	if err != nil {
+		return nil
+	}
+	var text bytes.Buffer
+	if err := tmpl.Execute(&text, data); err != nil {
+		return nil
+	}
+
+	indentation, ok := lineIndentation(pass, pos)
+	if !ok {
+		return nil
+	}
+	var comment strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(text.String(), "\n"), "\n") {
+		comment.WriteString(strings.TrimRight("// "+line, " "))
+		comment.WriteString("\n")
+		comment.WriteString(indentation)
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: "Add doc comment for " + data.Name,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     pos,
+			End:     pos,
+			NewText: []byte(comment.String()),
+		}},
+	}}
+}
+
+// lineIndentation returns the whitespace in front of the given position on its line.
+// It returns false if there is anything else than whitespace in front of the position.
+func lineIndentation(pass *analysis.Pass, pos token.Pos) (string, bool) {
+	tokenFile := pass.Fset.File(pos)
+	if tokenFile == nil {
+		return "", false
+	}
+
+	readFile := pass.ReadFile
+	if readFile == nil {
+		readFile = os.ReadFile
+	}
+	content, err := readFile(tokenFile.Name())
+	if err != nil {
+		return "", false
+	}
+
+	lineStart := tokenFile.Offset(tokenFile.LineStart(tokenFile.Line(pos)))
+	prefix := string(content[lineStart:tokenFile.Offset(pos)])
+	return prefix, strings.TrimLeft(prefix, " \t") == ""
+}
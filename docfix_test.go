@@ -0,0 +1,54 @@
+package qawaylinter
+
+import (
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSuggestedFixes(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"fixes"},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{RequireHeadlineComment: true},
+					}),
+					"interfaces": NewChecker[InterfaceRuleResults](InterfaceRule[InterfaceRuleResults]{
+						Params: InterfaceRuleParameters{RequireHeadlineComment: true, RequireMethodComment: true},
+					}),
+					"structs": NewChecker[StructRuleResults](StructRule[StructRuleResults]{
+						Params: StructRuleParameters{
+							RequireHeadlineComment: true,
+							RequireFieldComment:    true,
+							CommentTemplate:        "{{.Name}} is a {{.Kind}}.\nTODO: document {{.Name}}",
+						},
+					}),
+				},
+			},
+		},
+	}}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzers[0], "fixes")
+}
+
+func TestDecodeInvalidCommentTemplate(t *testing.T) {
+	_, err := DecodeSettings(map[string]any{
+		"rules": []any{map[string]any{
+			"packages":  []any{"example.com/foo"},
+			"functions": map[string]any{"params": map[string]any{"commentTemplate": "{{.Name"}},
+		}},
+	})
+	expected := "rules[0].functions.params.commentTemplate: template: comment:1: unclosed action"
+	if err == nil || err.Error() != expected {
+		t.Errorf("DecodeSettings() error = %v; want %s", err, expected)
+	}
+}
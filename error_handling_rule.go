@@ -0,0 +1,219 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"strings"
+)
+
+func init() {
+	RegisterJSONRule[ErrorHandlingRuleResults, ErrorHandlingRule[ErrorHandlingRuleResults]]("errorHandling")
+}
+
+type ErrorHandlingRuleParameters struct {
+	// RequireErrorHandling determines if each `if err != nil` branch must log the error, wrap it with `fmt.Errorf` and
+	// `%w` or `errors.Join`, or return it. A logging statement only counts if it refers to the error.
+	// Passing the error to `panic` is accepted as returning it.
+	RequireErrorHandling bool `json:"requireErrorHandling"`
+	// ReportDoubleHandling determines if branches that log the error and return it, wrapped or not, are reported.
+	// The error is then usually logged again by one of the callers.
+	ReportDoubleHandling bool `json:"reportDoubleHandling"`
+	// Logging configures how the logging statements in the branches are detected.
+	Logging LoggingDetection `json:"logging"`
+}
+
+// ErrorBranch describes how the error is handled in the body of an `if err != nil` statement.
+type ErrorBranch struct {
+	// Name of the error variable, e.g. `err`.
+	Name string
+	// Position of the if statement.
+	Pos token.Pos
+	// Indicates that the branch contains a logging statement.
+	Logged bool
+	// Indicates that a logging statement of the branch refers to the error.
+	LogsError bool
+	// Indicates that the error is wrapped with `fmt.Errorf` and `%w` or `errors.Join`.
+	Wrapped bool
+	// Indicates that the error is returned, wrapped or not, or passed to panic.
+	// A bare return in a function with named results is considered to return the error.
+	Returned bool
+}
+
+type ErrorHandlingRuleResults struct {
+	// Branches of all `if err != nil` statements in the function, including those of function literals,
+	// ordered by their position in the source.
+	Branches []ErrorBranch
+}
+
+// ErrorHandlingRule checks the branches of a function that handle errors, i.e. the bodies of `if err != nil` statements.
+type ErrorHandlingRule[ResultType ErrorHandlingRuleResults] struct {
+	Filters FunctionFilters             `json:"filters"`
+	Params  ErrorHandlingRuleParameters `json:"params"`
+}
+
+func (e ErrorHandlingRule[ResultType]) IsApplicable(node ast.Node, pass *analysis.Pass, _ *ast.File) bool {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil || !e.Filters.matchesDecl(funcDecl) {
+		return false
+	}
+	return e.Filters.MinLinesOfCode == 0 || countLinesInFunction(funcDecl, pass.Fset) >= e.Filters.MinLinesOfCode
+}
+
+func (e ErrorHandlingRule[ResultType]) Analyse(node ast.Node, pass *analysis.Pass, _ *ast.File) *ErrorHandlingRuleResults {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok {
+		return nil
+	}
+
+	return &ErrorHandlingRuleResults{
+		Branches: e.errorBranches(funcDecl.Body, hasNamedResults(funcDecl.Type), pass.TypesInfo),
+	}
+}
+
+func (e ErrorHandlingRule[ResultType]) Apply(analysis *ErrorHandlingRuleResults, _ ast.Node, pass *analysis.Pass) {
+	if analysis == nil {
+		return
+	}
+	for _, branch := range analysis.Branches {
+		if e.Params.RequireErrorHandling && !branch.LogsError && !branch.Wrapped && !branch.Returned {
+			reportf(pass, branch.Pos, "errorHandling/requireErrorHandling", "Error '%s' is neither logged, wrapped nor returned", branch.Name)
+		}
+		if e.Params.ReportDoubleHandling && branch.LogsError && branch.Returned {
+			reportf(pass, branch.Pos, "errorHandling/reportDoubleHandling", "Error '%s' is both logged and returned", branch.Name)
+		}
+	}
+}
+
+// RequiresTypesInfo determines if logging statements are detected using type information.
+func (e ErrorHandlingRule[ResultType]) RequiresTypesInfo() bool {
+	return e.Params.Logging.TypeAware
+}
+
+// errorBranches collects the `if err != nil` statements in the body of a function.
+// Function literals are analysed separately, as a bare return in them refers to their own results.
+func (e ErrorHandlingRule[ResultType]) errorBranches(body *ast.BlockStmt, namedResults bool, info *types.Info) []ErrorBranch {
+	var branches []ErrorBranch
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.FuncLit:
+			branches = append(branches, e.errorBranches(stmt.Body, hasNamedResults(stmt.Type), info)...)
+			return false
+		case *ast.IfStmt:
+			if name, ok := checkedError(stmt.Cond, info); ok {
+				branches = append(branches, e.analyseBranch(stmt, name, namedResults, info))
+			}
+		}
+		return true
+	})
+	return branches
+}
+
+// analyseBranch determines how the error with the given name is handled in the body of the if statement.
+func (e ErrorHandlingRule[ResultType]) analyseBranch(stmt *ast.IfStmt, name string, namedResults bool, info *types.Info) ErrorBranch {
+	branch := ErrorBranch{Name: name, Pos: stmt.Pos()}
+	ast.Inspect(stmt.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if (len(node.Results) == 0 && namedResults) || refersTo(node, name) {
+				branch.Returned = true
+			}
+		case *ast.CallExpr:
+			switch {
+			case e.Params.Logging.isLoggingCall(node, info):
+				branch.Logged = true
+				branch.LogsError = branch.LogsError || refersTo(node, name)
+			case isWrappingCall(node, name, info):
+				branch.Wrapped = true
+			case isPanicCall(node) && refersTo(node, name):
+				branch.Returned = true
+			}
+		}
+		return true
+	})
+	return branch
+}
+
+// checkedError returns the name of the error variable if the condition is `err != nil` or `nil != err`.
+// With type information, any variable of an error type is accepted. Otherwise, the variable must be named `err` or
+// end with `Err`, e.g. `closeErr`.
+func checkedError(cond ast.Expr, info *types.Info) (string, bool) {
+	binary, ok := cond.(*ast.BinaryExpr)
+	if !ok || binary.Op != token.NEQ {
+		return "", false
+	}
+	x, y := binary.X, binary.Y
+	if isNil(x) {
+		x, y = y, x
+	}
+	ident, ok := x.(*ast.Ident)
+	if !ok || !isNil(y) {
+		return "", false
+	}
+
+	if info != nil {
+		if t := info.TypeOf(ident); t != nil {
+			return ident.Name, types.Implements(t, types.Universe.Lookup("error").Type().Underlying().(*types.Interface))
+		}
+	}
+	return ident.Name, ident.Name == "err" || strings.HasSuffix(ident.Name, "Err")
+}
+
+// isNil determines if the expression is the identifier `nil`.
+func isNil(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+// refersTo determines if the node contains an identifier with the given name.
+func refersTo(node ast.Node, name string) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isWrappingCall determines if the call wraps the error with the given name, i.e. it is `fmt.Errorf` with a `%w` verb
+// or `errors.Join` and the error is one of its arguments.
+func isWrappingCall(call *ast.CallExpr, name string, info *types.Info) bool {
+	pkg, function := calledFunction(call, info)
+	switch {
+	case pkg == "fmt" && function == "Errorf":
+		if len(call.Args) == 0 {
+			return false
+		}
+		format, ok := call.Args[0].(*ast.BasicLit)
+		return ok && strings.Contains(format.Value, "%w") && anyRefersTo(call.Args[1:], name)
+	case pkg == "errors" && function == "Join":
+		return anyRefersTo(call.Args, name)
+	}
+	return false
+}
+
+// anyRefersTo determines if any of the expressions contains an identifier with the given name.
+func anyRefersTo(exprs []ast.Expr, name string) bool {
+	for _, expr := range exprs {
+		if refersTo(expr, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPanicCall determines if the call is a call of the builtin panic.
+func isPanicCall(call *ast.CallExpr) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ident.Name == "panic"
+}
+
+// hasNamedResults determines if a function declares named results, which are returned by a bare return.
+func hasNamedResults(funcType *ast.FuncType) bool {
+	return len(paramNames(funcType.Results)) > 0
+}
@@ -0,0 +1,79 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestErrorHandlingRule(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"errorhandling"},
+				Checks: map[string]Checker{
+					"errorHandling": NewChecker[ErrorHandlingRuleResults](ErrorHandlingRule[ErrorHandlingRuleResults]{
+						Params: ErrorHandlingRuleParameters{
+							RequireErrorHandling: true,
+							ReportDoubleHandling: true,
+							Logging:              LoggingDetection{TypeAware: true},
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "errorhandling")
+}
+
+func TestErrorHandlingWithoutTypesInfo(t *testing.T) {
+	fset := token.NewFileSet()
+	source := `package foo
+
+func handle() error {
+	if err := remove(); err != nil {
+		logger.Warnf("removing: %v", err)
+	}
+	if closeErr := close(); closeErr != nil {
+		return fmt.Errorf("closing: %w", closeErr)
+	}
+	if value != nil {
+		return nil
+	}
+	if nil != err {
+		cleanup()
+	}
+	return nil
+}
+`
+	f, err := parser.ParseFile(fset, "foo.go", source, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+	funcDecl := f.Decls[0].(*ast.FuncDecl)
+
+	var reported []string
+	pass := &analysis.Pass{Fset: fset, Report: func(d analysis.Diagnostic) {
+		reported = append(reported, fset.Position(d.Pos).String()+": "+d.Message)
+	}}
+	rule := ErrorHandlingRule[ErrorHandlingRuleResults]{Params: ErrorHandlingRuleParameters{RequireErrorHandling: true}}
+	rule.Apply(rule.Analyse(funcDecl, pass, f), funcDecl, pass)
+
+	expected := []string{"foo.go:13:2: Error 'err' is neither logged, wrapped nor returned"}
+	if !slices.Equal(reported, expected) {
+		t.Errorf("Expected %v, but got %v", expected, reported)
+	}
+}
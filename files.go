@@ -0,0 +1,82 @@
+package qawaylinter
+
+import (
+	"encoding/json"
+	"go/ast"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MatchesFile checks if the target applies to the given file.
+// The file must match any of the `includeFiles` patterns (if set) and none of the `excludeFiles` patterns.
+func (t Rules) MatchesFile(filename string) bool {
+	for _, pattern := range t.ExcludeFiles {
+		if matchesFilePattern(pattern, filename) {
+			return false
+		}
+	}
+	if len(t.IncludeFiles) == 0 {
+		return true
+	}
+	for _, pattern := range t.IncludeFiles {
+		if matchesFilePattern(pattern, filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesFilePattern matches a glob pattern as supported by filepath.Match against a file.
+// Patterns without a slash, e.g. `zz_generated*.go`, are matched against the name of the file.
+// Patterns with slashes, e.g. `mocks/*.go`, are matched against the same number of trailing path segments.
+func matchesFilePattern(pattern string, filename string) bool {
+	segments := strings.Split(filepath.ToSlash(filename), "/")
+	patternSegments := strings.Count(pattern, "/") + 1
+	if patternSegments > len(segments) {
+		return false
+	}
+	name := strings.Join(segments[len(segments)-patternSegments:], "/")
+	matched, _ := filepath.Match(pattern, name)
+	return matched
+}
+
+// decodeFilePatterns decodes a list of file patterns and validates their syntax.
+func decodeFilePatterns(data json.RawMessage, patterns *[]string) error {
+	if err := decodeJSON(data, reflect.ValueOf(patterns).Elem()); err != nil {
+		return err
+	}
+	for i, pattern := range *patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return &ConfigError{Path: "[" + strconv.Itoa(i) + "]", Err: err}
+		}
+	}
+	return nil
+}
+
+// isTestFile checks if the file contains tests.
+func isTestFile(filename string) bool {
+	return strings.HasSuffix(filename, "_test.go")
+}
+
+// isTestEntryPoint checks if the function is executed by `go test`, e.g. `TestXxx` or `BenchmarkXxx`.
+// These functions are documented by their name and are therefore never checked.
+func isTestEntryPoint(funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Recv != nil {
+		return false
+	}
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz", "Example"} {
+		name, ok := strings.CutPrefix(funcDecl.Name.Name, prefix)
+		if !ok {
+			continue
+		}
+		// as in `go test`, the name must not continue with a lower case letter, e.g. `Testify` is not a test.
+		if first, _ := utf8.DecodeRuneInString(name); name == "" || !unicode.IsLower(first) {
+			return true
+		}
+	}
+	return false
+}
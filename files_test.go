@@ -0,0 +1,87 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileFilters(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	rule := NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+		Params: FunctionRuleParameters{RequireHeadlineComment: true},
+	})
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages:     []string{"files"},
+				ExcludeFiles: []string{"mock_*.go"},
+				Checks:       map[string]Checker{"functions": rule},
+				Tests:        &Rules{Checks: map[string]Checker{"functions": rule}},
+			},
+		},
+	}}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "files")
+}
+
+func TestMatchesFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   Rules
+		filename string
+		expected bool
+	}{
+		{name: "No patterns", target: Rules{}, filename: "/src/foo/service.go", expected: true},
+		{name: "Excluded by name", target: Rules{ExcludeFiles: []string{"*_mock.go"}}, filename: "/src/foo/service_mock.go", expected: false},
+		{name: "Excluded by directory", target: Rules{ExcludeFiles: []string{"mocks/*.go"}}, filename: "/src/foo/mocks/service.go", expected: false},
+		{name: "Not included", target: Rules{IncludeFiles: []string{"api_*.go"}}, filename: "/src/foo/service.go", expected: false},
+		{name: "Included", target: Rules{IncludeFiles: []string{"api_*.go"}}, filename: "/src/foo/api_users.go", expected: true},
+		{name: "Exclusion takes precedence", target: Rules{IncludeFiles: []string{"api_*.go"}, ExcludeFiles: []string{"api_internal.go"}}, filename: "/src/foo/api_internal.go", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := tt.target.MatchesFile(tt.filename); actual != tt.expected {
+				t.Errorf("MatchesFile(%q) = %v; want %v", tt.filename, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsTestEntryPoint(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected bool
+	}{
+		{source: "func TestFoo(t *testing.T) {}", expected: true},
+		{source: "func Test_foo(t *testing.T) {}", expected: true},
+		{source: "func TestMain(m *testing.M) {}", expected: true},
+		{source: "func BenchmarkFoo(b *testing.B) {}", expected: true},
+		{source: "func ExampleFoo() {}", expected: true},
+		{source: "func Testify() {}", expected: false},
+		{source: "func NewFixture() {}", expected: false},
+		{source: "func (s *Suite) TestFoo() {}", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			f, err := parser.ParseFile(token.NewFileSet(), "", "package foo\n"+tt.source, 0)
+			if err != nil {
+				t.Fatalf("Failed to parse source: %s", err)
+			}
+			if actual := isTestEntryPoint(f.Decls[0].(*ast.FuncDecl)); actual != tt.expected {
+				t.Errorf("isTestEntryPoint(%q) = %v; want %v", tt.source, actual, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package qawaylinter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"regexp"
+)
+
+// Pattern is a regular expression in the configuration of a rule, e.g. in `includeNames`.
+// It is compiled when the configuration is decoded, so invalid expressions are reported as configuration errors.
+type Pattern struct {
+	regexp *regexp.Regexp
+}
+
+// UnmarshalJSON compiles the regular expression.
+func (p *Pattern) UnmarshalJSON(data []byte) error {
+	var expr string
+	if err := json.Unmarshal(data, &expr); err != nil {
+		return fmt.Errorf("expected string, got %s", data)
+	}
+	compiled, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid regular expression: %w", err)
+	}
+	p.regexp = compiled
+	return nil
+}
+
+// JSONSchema describes a pattern as string.
+func (p Pattern) JSONSchema() map[string]any {
+	return map[string]any{"type": "string", "format": "regex"}
+}
+
+// isSet checks if an expression has been configured.
+func (p Pattern) isSet() bool {
+	return p.regexp != nil
+}
+
+// matches checks if the expression matches any part of the given name.
+func (p Pattern) matches(name string) bool {
+	return p.regexp.MatchString(name)
+}
+
+// NameFilters restrict a rule to symbols by their name. They are part of the filters of all rules.
+type NameFilters struct {
+	// ExportedOnly restricts the rule to exported symbols.
+	ExportedOnly bool `json:"exportedOnly"`
+	// UnexportedOnly restricts the rule to unexported symbols.
+	UnexportedOnly bool `json:"unexportedOnly"`
+	// IncludeNames restricts the rule to symbols whose name matches the regular expression.
+	IncludeNames Pattern `json:"includeNames"`
+	// ExcludeNames excludes symbols whose name matches the regular expression from the rule.
+	ExcludeNames Pattern `json:"excludeNames"`
+}
+
+// Validate rejects filters that exclude every symbol.
+func (f NameFilters) Validate() error {
+	if f.ExportedOnly && f.UnexportedOnly {
+		return errors.New("exportedOnly and unexportedOnly are mutually exclusive")
+	}
+	return nil
+}
+
+// matchesName checks if a symbol with the given name passes the filters.
+func (f NameFilters) matchesName(name string) bool {
+	if f.ExportedOnly && !ast.IsExported(name) {
+		return false
+	}
+	if f.UnexportedOnly && ast.IsExported(name) {
+		return false
+	}
+	if f.IncludeNames.isSet() && !f.IncludeNames.matches(name) {
+		return false
+	}
+	return !f.ExcludeNames.isSet() || !f.ExcludeNames.matches(name)
+}
@@ -0,0 +1,41 @@
+package qawaylinter
+
+import (
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilters(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	settings, err := DecodeSettings(map[string]any{"rules": []any{
+		map[string]any{
+			"packages": []any{"filters"},
+			"functions": map[string]any{
+				"filters": map[string]any{"exportedOnly": true, "excludeNames": "Mock$", "exportedReceiversOnly": true, "excludeReceivers": "Handler$"},
+				"params":  map[string]any{"requireHeadlineComment": true},
+			},
+			"interfaces": map[string]any{
+				"filters": map[string]any{"exportedOnly": true, "minMethods": 1},
+				"params":  map[string]any{"requireHeadlineComment": true, "requireMethodComment": true},
+			},
+			"structs": map[string]any{
+				"filters": map[string]any{"exportedOnly": true, "minFields": 1},
+				"params":  map[string]any{"requireHeadlineComment": true, "requireFieldComment": true},
+			},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to decode settings: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: settings}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "filters")
+}
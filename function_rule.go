@@ -1,36 +1,135 @@
 package qawaylinter
 
 import (
+	"errors"
 	"go/ast"
 	"go/scanner"
 	"go/token"
+	"go/types"
 	"golang.org/x/tools/go/analysis"
 	"log"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 )
 
-// patterns for determining logger calls. the (?i) in the regex makes the regex case-insensitive.
-var loggerPattern = regexp.MustCompile("(?i)(log|logger)")
+func init() {
+	RegisterJSONRule[FunctionRuleResults, FunctionRule[FunctionRuleResults]]("functions")
+}
 
-// the method pattern also covers calls like Printf etc. as (?i)print also matches Printf.
-var loggerMethodPattern = regexp.MustCompile("(?i)(debug|info|warn|error|fatal|print|panic|trace|log)")
+// errorDescriptionPattern determines if a headline comment describes the error conditions of a function,
+// e.g. `returns an error if ...` or `fails if ...`.
+var errorDescriptionPattern = regexp.MustCompile(`(?i)\b(err\w*|fail\w*)\b`)
 
 type FunctionFilters struct {
+	NameFilters
 	// MinLinesOfCode determines the minimum number of lines of code that a function must have to be considered.
-	MinLinesOfCode int `json:"minLinesOfCode"`
+	MinLinesOfCode int `json:"minLinesOfCode" minimum:"0"`
+	// FunctionsOnly restricts the rule to functions without receiver.
+	FunctionsOnly bool `json:"functionsOnly"`
+	// MethodsOnly restricts the rule to methods.
+	MethodsOnly bool `json:"methodsOnly"`
+	// ExportedReceiversOnly restricts the rule for methods to methods on exported types. Functions are not affected.
+	ExportedReceiversOnly bool `json:"exportedReceiversOnly"`
+	// UnexportedReceiversOnly restricts the rule for methods to methods on unexported types. Functions are not affected.
+	UnexportedReceiversOnly bool `json:"unexportedReceiversOnly"`
+	// IncludeReceivers restricts the rule for methods to receiver types matching the regular expression, e.g. `Handler$`.
+	// Functions are not affected.
+	IncludeReceivers Pattern `json:"includeReceivers"`
+	// ExcludeReceivers excludes methods on receiver types matching the regular expression from the rule.
+	ExcludeReceivers Pattern `json:"excludeReceivers"`
+}
+
+// Validate rejects filters that exclude every function.
+func (f FunctionFilters) Validate() error {
+	switch {
+	case f.FunctionsOnly && f.MethodsOnly:
+		return errors.New("functionsOnly and methodsOnly are mutually exclusive")
+	case f.ExportedReceiversOnly && f.UnexportedReceiversOnly:
+		return errors.New("exportedReceiversOnly and unexportedReceiversOnly are mutually exclusive")
+	}
+	return f.NameFilters.Validate()
+}
+
+// matchesDecl checks if the declaration of a function passes the filters. The lines of code are checked separately.
+func (f FunctionFilters) matchesDecl(funcDecl *ast.FuncDecl) bool {
+	if !f.matchesName(funcDecl.Name.Name) {
+		return false
+	}
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return !f.MethodsOnly
+	}
+	if f.FunctionsOnly {
+		return false
+	}
+
+	receiver := typeName(funcDecl.Recv.List[0].Type)
+	switch {
+	case f.ExportedReceiversOnly && !ast.IsExported(receiver):
+		return false
+	case f.UnexportedReceiversOnly && ast.IsExported(receiver):
+		return false
+	case f.IncludeReceivers.isSet() && !f.IncludeReceivers.matches(receiver):
+		return false
+	case f.ExcludeReceivers.isSet() && f.ExcludeReceivers.matches(receiver):
+		return false
+	}
+	return true
 }
 
 type FunctionRuleParameters struct {
 	// RequireHeadlineComment determines if a comment must be placed on top of the function.
 	RequireHeadlineComment bool `json:"requireHeadlineComment"`
 	// MinHeadlineCommentDensity determines the minimum percentage of comments in the headline of the function compared to the body length.
-	MinHeadlineCommentDensity float64 `json:"minHeadlineCommentDensity"`
+	MinHeadlineCommentDensity float64 `json:"minHeadlineCommentDensity" minimum:"0" maximum:"1"`
 	// MinCommentDensity determines the minimum percentage of comments in the body of the function compared to the body length.
-	MinCommentDensity       float64 `json:"minCommentDensity"`
-	TrivialCommentThreshold float64 `json:"trivialCommentThreshold"`
-	MinLoggingDensity       float64 `json:"minLoggingDensity"`
+	MinCommentDensity       float64 `json:"minCommentDensity" minimum:"0" maximum:"1"`
+	TrivialCommentThreshold float64 `json:"trivialCommentThreshold" minimum:"0" maximum:"1"`
+	// SimilarityMetric determines how the similarity for TrivialCommentThreshold is measured:
+	// `levenshtein` (default), `jaccard` or `jaroWinkler`.
+	SimilarityMetric  string  `json:"similarityMetric"`
+	MinLoggingDensity float64 `json:"minLoggingDensity" minimum:"0" maximum:"1"`
+	// Logging configures how the logging statements for MinLoggingDensity are detected.
+	Logging LoggingDetection `json:"logging"`
+	// RequireTypeParamMention determines if the headline comment of a generic function must mention each of its type
+	// parameters, e.g. `T` and `U` for `func Map[T, U any](...)`.
+	RequireTypeParamMention bool `json:"requireTypeParamMention"`
+	// RequireParamMention determines if the headline comment must mention each named parameter of the function.
+	RequireParamMention bool `json:"requireParamMention"`
+	// MinParamsForMention determines the minimum number of parameters, not counting IgnoredParams, a function must have
+	// for RequireParamMention to be checked.
+	MinParamsForMention int `json:"minParamsForMention" minimum:"0"`
+	// IgnoredParams are parameters that do not have to be mentioned, e.g. `ctx`.
+	IgnoredParams []string `json:"ignoredParams"`
+	// RequireErrorDoc determines if the headline comment of a function returning an error must describe the error
+	// conditions, i.e. mention errors or failures.
+	RequireErrorDoc bool `json:"requireErrorDoc"`
+	// RequireNamedResultMention determines if the headline comment must mention each named result of the function.
+	RequireNamedResultMention bool `json:"requireNamedResultMention"`
+	// RequireGodocConvention determines if the headline comment must follow the Go doc comment conventions: it must
+	// start with the name of the function, its first paragraph must be a full sentence and it must not be a bare TODO.
+	RequireGodocConvention bool `json:"requireGodocConvention"`
+	// MinCommentedOutCodeLines determines the number of lines from which on a block of commented-out code in the
+	// function or its headline comment is reported. Commented-out code never counts as comment. 0 disables the check.
+	MinCommentedOutCodeLines int `json:"minCommentedOutCodeLines" minimum:"0"`
+	// IgnoreCommentPrefixes are prefixes of comment lines that are not counted as documentation, e.g. `TODO`.
+	// Directives, blank lines and license headers are never counted.
+	IgnoreCommentPrefixes []string `json:"ignoreCommentPrefixes"`
+	// CommentTemplate is the text/template of the doc comment that is suggested as fix for a missing headline comment.
+	// The template receives CommentTemplateData. Defaults to `{{.Name}} ...`.
+	CommentTemplate string `json:"commentTemplate"`
+}
+
+// Validate ensures that the comment template can be parsed and that the similarity metric is known.
+func (p FunctionRuleParameters) Validate() error {
+	if _, err := parseCommentTemplate(p.CommentTemplate); err != nil {
+		return &ConfigError{Path: "commentTemplate", Err: err}
+	}
+	if err := validateSimilarityMetric(p.SimilarityMetric); err != nil {
+		return &ConfigError{Path: "similarityMetric", Err: err}
+	}
+	return nil
 }
 
 type FunctionRuleResults struct {
@@ -41,9 +140,33 @@ type FunctionRuleResults struct {
 	// Number of lines of comments in the body of the function.
 	BodyComments int
 	// Indicates the similarity between the method name and the headline comments.
-	CommentSimilarity float64
+	CommentSimilarity Similarity
 	// Number of logging statements in the function.
 	LoggingStatements int
+	// Type parameters of the function that are not mentioned in the headline comment.
+	UnmentionedTypeParams []string
+	// Names of the parameters of the function. Blank and unnamed parameters are not included.
+	Params []string
+	// Parameters of the function that are not mentioned in the headline comment.
+	UnmentionedParams []string
+	// Named results of the function that are not mentioned in the headline comment.
+	UnmentionedResults []string
+	// Indicates that the last result of the function is an error.
+	ReturnsError bool
+	// Indicates that the headline comment mentions errors or failures.
+	DescribesErrors bool
+	// Comment groups in the headline or body of the function that contain commented-out code.
+	CommentedOutCode []CodeBlock
+}
+
+// CodeBlock describes the position of commented-out code.
+type CodeBlock struct {
+	// Start of the comment group.
+	Pos token.Pos
+	// End of the comment group.
+	End token.Pos
+	// Number of lines of the comment group.
+	Lines int
 }
 
 type FunctionRule[ResultType FunctionRuleResults] struct {
@@ -54,7 +177,8 @@ type FunctionRule[ResultType FunctionRuleResults] struct {
 }
 
 func (f FunctionRule[ResultType]) IsApplicable(node ast.Node, pass *analysis.Pass, file *ast.File) bool {
-	if _, ok := node.(*ast.FuncDecl); !ok {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || !f.Filters.matchesDecl(funcDecl) {
 		return false
 	}
 
@@ -82,22 +206,30 @@ func (f FunctionRule[ResultType]) Analyse(node ast.Node, pass *analysis.Pass, fi
 	}
 
 	linesInFunction := countLinesInFunction(funcDecl, pass.Fset)
-	linesOfCommentsInMethodBody := countInlineCommentsInFunction(funcDecl, file.Comments, pass.Fset)
-	loggingStatements := countLoggingStatementsInFunction(funcDecl)
+	linesOfCommentsInMethodBody := countInlineCommentsInFunction(funcDecl, file.Comments, f.Params.IgnoreCommentPrefixes)
+	loggingStatements := countLoggingStatementsInFunction(funcDecl, f.Params.Logging, pass.TypesInfo)
 
 	linesOfHeadlineComments := 0
-	if funcDecl.Doc != nil {
-		linesOfHeadlineComments = countCommentLines(funcDecl.Doc, pass.Fset)
+	if funcDecl.Doc != nil && !isCommentedOutCode(funcDecl.Doc) {
+		linesOfHeadlineComments = countCommentLines(funcDecl.Doc, f.Params.IgnoreCommentPrefixes)
 	}
 
-	commentSimilarity := StringSimilarity(funcDecl.Name.Name, funcDecl.Doc.Text())
+	commentSimilarity := CommentSimilarity(funcDecl.Name.Name, funcDecl.Doc.Text(), f.Params.SimilarityMetric)
+	params := paramNames(funcDecl.Type.Params)
 
 	return &FunctionRuleResults{
-		HeadlineComments:  linesOfHeadlineComments,
-		BodyLinesOfCode:   linesInFunction,
-		BodyComments:      linesOfCommentsInMethodBody,
-		CommentSimilarity: commentSimilarity,
-		LoggingStatements: loggingStatements,
+		HeadlineComments:      linesOfHeadlineComments,
+		BodyLinesOfCode:       linesInFunction,
+		BodyComments:          linesOfCommentsInMethodBody,
+		CommentSimilarity:     commentSimilarity,
+		LoggingStatements:     loggingStatements,
+		UnmentionedTypeParams: unmentionedNames(funcDecl.Doc.Text(), typeParamNames(funcDecl.Type.TypeParams)),
+		Params:                params,
+		UnmentionedParams:     unmentionedNames(funcDecl.Doc.Text(), params),
+		UnmentionedResults:    unmentionedNames(funcDecl.Doc.Text(), paramNames(funcDecl.Type.Results)),
+		ReturnsError:          returnsError(funcDecl.Type),
+		DescribesErrors:       errorDescriptionPattern.MatchString(funcDecl.Doc.Text()),
+		CommentedOutCode:      findCommentedOutCode(funcDecl, file.Comments, pass.Fset),
 	}
 }
 
@@ -107,22 +239,67 @@ func (f FunctionRule[ResultType]) Apply(analysis *FunctionRuleResults, node ast.
 	}
 	funcDecl := node.(*ast.FuncDecl)
 	if analysis.HeadlineComments == 0 && f.Params.RequireHeadlineComment {
-		pass.Reportf(node.Pos(), "Method '%s' is missing required headline comment", funcDecl.Name.Name)
+		kind := "function"
+		if funcDecl.Recv != nil {
+			kind = "method"
+		}
+		fixes := suggestDocComment(pass, funcDecl.Pos(), f.Params.CommentTemplate, CommentTemplateData{Name: funcDecl.Name.Name, Kind: kind})
+		reportWithFixesf(pass, node.Pos(), "functions/requireHeadlineComment", fixes, "Method '%s' is missing required headline comment", funcDecl.Name.Name)
 	}
 	if analysis.CommentDensity() < f.Params.MinCommentDensity {
-		pass.Reportf(node.Pos(), "Method '%s' has less than %.0f%% comment density. Actual: %.0f%%", funcDecl.Name.Name, f.Params.MinCommentDensity*100, analysis.CommentDensity()*100)
+		reportf(pass, node.Pos(), "functions/minCommentDensity", "Method '%s' has less than %.0f%% comment density. Actual: %.0f%%", funcDecl.Name.Name, f.Params.MinCommentDensity*100, analysis.CommentDensity()*100)
 	}
 	if analysis.HeadlineCommentDensity() < f.Params.MinHeadlineCommentDensity {
-		pass.Reportf(node.Pos(), "Method '%s' has less than %.0f%% headline comment density. Actual: %.0f%%", funcDecl.Name.Name, f.Params.MinHeadlineCommentDensity*100, analysis.HeadlineCommentDensity()*100)
+		reportf(pass, node.Pos(), "functions/minHeadlineCommentDensity", "Method '%s' has less than %.0f%% headline comment density. Actual: %.0f%%", funcDecl.Name.Name, f.Params.MinHeadlineCommentDensity*100, analysis.HeadlineCommentDensity()*100)
+	}
+	if analysis.HeadlineComments > 0 && f.Params.RequireGodocConvention {
+		reportGodocIssues(pass, node.Pos(), "functions/requireGodocConvention", funcDecl.Doc, funcDecl.Name.Name, false)
+	}
+	if f.Params.TrivialCommentThreshold > 0 && analysis.CommentSimilarity.Score > f.Params.TrivialCommentThreshold {
+		reportf(pass, node.Pos(), "functions/trivialCommentThreshold", "Method '%s' has a trivial comment. Similarity to method name: %.0f%%%s", funcDecl.Name.Name, analysis.CommentSimilarity.Score*100, explanationSuffix(analysis.CommentSimilarity))
+	}
+	if analysis.HeadlineComments > 0 && f.Params.RequireTypeParamMention {
+		for _, typeParam := range analysis.UnmentionedTypeParams {
+			reportf(pass, node.Pos(), "functions/requireTypeParamMention", "Method '%s' does not mention type parameter '%s' in its headline comment", funcDecl.Name.Name, typeParam)
+		}
+	}
+	if analysis.HeadlineComments > 0 && f.Params.RequireParamMention {
+		params := slices.DeleteFunc(slices.Clone(analysis.Params), func(param string) bool { return slices.Contains(f.Params.IgnoredParams, param) })
+		if len(params) >= f.Params.MinParamsForMention {
+			for _, param := range analysis.UnmentionedParams {
+				if slices.Contains(params, param) {
+					reportf(pass, node.Pos(), "functions/requireParamMention", "Method '%s' does not mention parameter '%s' in its headline comment", funcDecl.Name.Name, param)
+				}
+			}
+		}
+	}
+	if analysis.HeadlineComments > 0 && f.Params.RequireErrorDoc && analysis.ReturnsError && !analysis.DescribesErrors {
+		reportf(pass, node.Pos(), "functions/requireErrorDoc", "Method '%s' returns an error, but its headline comment does not describe when", funcDecl.Name.Name)
+	}
+	if analysis.HeadlineComments > 0 && f.Params.RequireNamedResultMention {
+		for _, result := range analysis.UnmentionedResults {
+			reportf(pass, node.Pos(), "functions/requireNamedResultMention", "Method '%s' does not mention named result '%s' in its headline comment", funcDecl.Name.Name, result)
+		}
 	}
-	if f.Params.TrivialCommentThreshold > 0 && analysis.CommentSimilarity > f.Params.TrivialCommentThreshold {
-		pass.Reportf(node.Pos(), "Method '%s' has a trivial comment. Similarity to method name: %.0f%%", funcDecl.Name.Name, analysis.CommentSimilarity*100)
+	if f.Params.MinCommentedOutCodeLines > 0 {
+		for _, block := range analysis.CommentedOutCode {
+			if block.Lines >= f.Params.MinCommentedOutCodeLines {
+				start, end := pass.Fset.Position(block.Pos).Line, pass.Fset.Position(block.End).Line
+				reportRangef(pass, block.Pos, block.End, "functions/minCommentedOutCodeLines", "Method '%s' contains %d lines of commented-out code in lines %d-%d", funcDecl.Name.Name, block.Lines, start, end)
+			}
+		}
 	}
 	if f.Params.MinLoggingDensity > 0 && analysis.LoggingDensity() < f.Params.MinLoggingDensity {
-		pass.Reportf(node.Pos(), "Method '%s' has less than %.0f%% logging density. Actual: %.0f%%", funcDecl.Name.Name, f.Params.MinLoggingDensity*100, analysis.LoggingDensity()*100)
+		reportf(pass, node.Pos(), "functions/minLoggingDensity", "Method '%s' has less than %.0f%% logging density. Actual: %.0f%%", funcDecl.Name.Name, f.Params.MinLoggingDensity*100, analysis.LoggingDensity()*100)
 	}
 }
 
+// RequiresTypesInfo determines if logging statements are detected using type information.
+// As targets inherit parameters from each other, MinLoggingDensity is not considered here.
+func (f FunctionRule[ResultType]) RequiresTypesInfo() bool {
+	return f.Params.Logging.TypeAware
+}
+
 func (r FunctionRuleResults) CommentDensity() float64 {
 	if r.BodyLinesOfCode == 0 {
 		return 0
@@ -144,24 +321,67 @@ func (r FunctionRuleResults) LoggingDensity() float64 {
 	return float64(r.LoggingStatements) / float64(r.BodyLinesOfCode)
 }
 
+// paramNames returns the names of the parameters or results of a function. Blank identifiers are not included.
+func paramNames(fields *ast.FieldList) []string {
+	if fields == nil {
+		return nil
+	}
+	var names []string
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			if name.Name != "_" {
+				names = append(names, name.Name)
+			}
+		}
+	}
+	return names
+}
+
+// returnsError determines if the last result of a function is of type error.
+func returnsError(funcType *ast.FuncType) bool {
+	if funcType.Results == nil || len(funcType.Results.List) == 0 {
+		return false
+	}
+	last, ok := funcType.Results.List[len(funcType.Results.List)-1].Type.(*ast.Ident)
+	return ok && last.Name == "error"
+}
+
 // countInlineCommentsInFunction determines the number of lines of comments that are part of the method body.
 // These comments are not returned as part of the AST of a FuncDecl.
 // But all comments within a given file are available in the file's comments.
 // This function determines the number of lines of comment within a method body by checking the comments in the file.
-func countInlineCommentsInFunction(f *ast.FuncDecl, commentsInFile []*ast.CommentGroup, fset *token.FileSet) int {
+func countInlineCommentsInFunction(f *ast.FuncDecl, commentsInFile []*ast.CommentGroup, ignorePrefixes []string) int {
 	commentLines := 0
 	for _, comment := range commentsInFile {
-		if (comment.Pos() >= f.Pos()) && (comment.End() <= f.End()) {
-			commentLines += countCommentLines(comment, fset)
+		if (comment.Pos() >= f.Pos()) && (comment.End() <= f.End()) && !isCommentedOutCode(comment) {
+			commentLines += countCommentLines(comment, ignorePrefixes)
 		}
 	}
 	return commentLines
 }
 
-func countLoggingStatementsInFunction(f *ast.FuncDecl) int {
+// findCommentedOutCode returns the comment groups in the headline comment and the body of a function that contain
+// commented-out code.
+func findCommentedOutCode(f *ast.FuncDecl, commentsInFile []*ast.CommentGroup, fset *token.FileSet) []CodeBlock {
+	var blocks []CodeBlock
+	for _, comment := range commentsInFile {
+		inFunction := comment.Pos() >= f.Pos() && comment.End() <= f.End()
+		if (inFunction || comment == f.Doc) && isCommentedOutCode(comment) {
+			blocks = append(blocks, CodeBlock{
+				Pos:   comment.Pos(),
+				End:   comment.End(),
+				Lines: fset.Position(comment.End()).Line - fset.Position(comment.Pos()).Line + 1,
+			})
+		}
+	}
+	return blocks
+}
+
+// countLoggingStatementsInFunction counts the logging calls in a function as detected by the given configuration.
+func countLoggingStatementsInFunction(f *ast.FuncDecl, detection LoggingDetection, info *types.Info) int {
 	loggingStatements := 0
 	ast.Inspect(f, func(n ast.Node) bool {
-		if isLoggingStatement(n) {
+		if detection.isLoggingCall(n, info) {
 			loggingStatements++
 		}
 		return true
@@ -169,29 +389,6 @@ func countLoggingStatementsInFunction(f *ast.FuncDecl) int {
 	return loggingStatements
 }
 
-// isLoggingStatements determines whether a given node contains a logging statement.
-// It first ensures that the node is of the correct types.
-func isLoggingStatement(n ast.Node) bool {
-	callExpr, ok := n.(*ast.CallExpr)
-	if !ok {
-		return false
-	}
-
-	// selExpr.Sel.Name contains the method that is called on the logger, e.g. `Warnf`
-	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
-	if !ok {
-		return false
-	}
-
-	// selExpr.X.Name contains struct that is called (e.g.) `log`
-	x, ok := selExpr.X.(*ast.Ident)
-	if !ok {
-		return false
-	}
-
-	return loggerPattern.MatchString(x.Name) && loggerMethodPattern.MatchString(selExpr.Sel.Name)
-}
-
 // countLinesInFunction counts the lines between the start and end of a given function declaration.
 // Note that this method also includes comments.
 func countLinesInFunction(funcDecl *ast.FuncDecl, fset *token.FileSet) int {
@@ -245,29 +442,3 @@ func countMeaningfulLines(source string) int {
 	}
 	return loc
 }
-
-// countCommentLines counts the lines covered by comments in a given AST node.
-// This method takes into account that a command can span multiple lines using the /* */ syntax.
-// It can count the number of comments in both the headline and within a method's body.
-func countCommentLines(node ast.Node, fset *token.FileSet) int {
-	commentLines := 0
-
-	// Traverse the node to find all comment groups
-	ast.Inspect(node, func(n ast.Node) bool {
-		if commentGroup, ok := n.(*ast.CommentGroup); ok {
-			for _, comment := range commentGroup.List {
-				if strings.HasPrefix(comment.Text, "// want `") {
-					// filter comments that are used for testing as these comments for analysistest
-					// would otherwise increase the logging density.
-					continue
-				}
-				start := fset.Position(comment.Pos()).Line
-				end := fset.Position(comment.End()).Line
-				commentLines += end - start + 1
-			}
-		}
-		return true
-	})
-
-	return commentLines
-}
@@ -18,13 +18,17 @@ func TestFunctionRule(t *testing.T) {
 		Targets: []Rules{
 			{
 				Packages: []string{"functions"},
-				FunctionRule: &FunctionRule[FunctionRuleResults]{
-					Params: FunctionRuleParameters{
-						RequireHeadlineComment:  true,
-						MinCommentDensity:       0.1,
-						TrivialCommentThreshold: 0.3,
-						MinLoggingDensity:       0.1,
-					},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{
+							RequireHeadlineComment:  true,
+							MinCommentDensity:       0.1,
+							TrivialCommentThreshold: 0.3,
+							MinLoggingDensity:       0.1,
+							// expectations of analysistest are not part of the documentation.
+							IgnoreCommentPrefixes: []string{"want `"},
+						},
+					}),
 				},
 			},
 		},
@@ -33,3 +37,33 @@ func TestFunctionRule(t *testing.T) {
 	analyzers, err := plugin.BuildAnalyzers()
 	analysistest.Run(t, testdata, analyzers[0], "functions")
 }
+
+func TestFunctionRuleMentions(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"params"},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{
+							RequireParamMention:       true,
+							MinParamsForMention:       2,
+							IgnoredParams:             []string{"ctx"},
+							RequireErrorDoc:           true,
+							RequireNamedResultMention: true,
+						},
+					}),
+				},
+			},
+		},
+	}}
+
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "params")
+}
@@ -0,0 +1,59 @@
+package qawaylinter
+
+import (
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestTypeParamMentions(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"generics"},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{RequireTypeParamMention: true},
+					}),
+					"interfaces": NewChecker[InterfaceRuleResults](InterfaceRule[InterfaceRuleResults]{
+						Params: InterfaceRuleParameters{RequireTypeParamMention: true, RequireConstraintComment: true},
+					}),
+					"structs": NewChecker[StructRuleResults](StructRule[StructRuleResults]{
+						Params: StructRuleParameters{RequireTypeParamMention: true},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "generics")
+}
+
+func TestUnmentionedNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		names    []string
+		expected []string
+	}{
+		{name: "Mentioned", text: "Map maps values of type T to K.", names: []string{"T", "K"}, expected: nil},
+		{name: "Part of a word", text: "Map maps Type values to T_1.", names: []string{"T"}, expected: []string{"T"}},
+		{name: "Punctuation", text: "Map maps (T) to [K].", names: []string{"T", "K"}, expected: nil},
+		{name: "Not mentioned", text: "Map maps values.", names: []string{"T", "K"}, expected: []string{"T", "K"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := unmentionedNames(tt.text, tt.names); !slices.Equal(actual, tt.expected) {
+				t.Errorf("unmentionedNames(%q, %v) = %v; want %v", tt.text, tt.names, actual, tt.expected)
+			}
+		})
+	}
+}
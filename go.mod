@@ -3,12 +3,11 @@ module github.com/qaware/qaway-linter
 go 1.23
 
 require (
+	github.com/adrg/strutil v0.3.1
 	github.com/golangci/plugin-module-register v0.1.1
+	golang.org/x/mod v0.22.0
 	golang.org/x/tools v0.28.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	github.com/adrg/strutil v0.3.1 // indirect
-	golang.org/x/mod v0.22.0 // indirect
-	golang.org/x/sync v0.10.0 // indirect
-)
+require golang.org/x/sync v0.10.0 // indirect
@@ -0,0 +1,90 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/token"
+	"golang.org/x/tools/go/analysis"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// articles may precede the name of a type in its doc comment, e.g. `// A Request is ...`.
+var articles = []string{"A", "An", "The"}
+
+// todoPattern matches doc comments that only consist of a TODO note, e.g. `// TODO`, `// TODO: document` or
+// `// TODO(alice): document`. A sentence such as `// Todo returns the next item.` is not a TODO note.
+var todoPattern = regexp.MustCompile(`^(?i:todo)(\([^)]*\))?(:|$)`)
+
+// godocIssue describes a violation of the Go doc comment conventions.
+type godocIssue struct {
+	message string
+	fixes   []analysis.SuggestedFix
+}
+
+// checkGodocConvention checks a doc comment against the Go doc comment conventions (see https://go.dev/doc/comment):
+// the comment must not be a bare TODO, it must start with the name of the symbol and its first paragraph must be a full
+// sentence. If allowArticle is set, the name may be preceded by `A`, `An` or `The` as it is common for types.
+// A case mismatch of the name comes with a suggested fix that rewrites the first word.
+func checkGodocConvention(doc *ast.CommentGroup, name string, allowArticle bool) []godocIssue {
+	text := strings.TrimSpace(doc.Text())
+	if text == "" {
+		return nil
+	}
+	if todoPattern.MatchString(text) && !startsWithName(text, name) {
+		return []godocIssue{{message: "is a bare TODO"}}
+	}
+
+	var issues []godocIssue
+	words := strings.Fields(text)
+	if allowArticle && len(words) > 1 && slices.Contains(articles, words[0]) {
+		words = words[1:]
+	}
+	if word := strings.TrimRight(words[0], ".,:;"); word != name {
+		issue := godocIssue{message: "should start with '" + name + "'"}
+		if strings.EqualFold(word, name) {
+			issue.fixes = renameFirstWord(doc, word, name)
+		}
+		issues = append(issues, issue)
+	}
+
+	firstParagraph, _, _ := strings.Cut(text, "\n\n")
+	if !strings.HasSuffix(firstParagraph, ".") && !strings.HasSuffix(firstParagraph, "!") && !strings.HasSuffix(firstParagraph, "?") {
+		issues = append(issues, godocIssue{message: "should be a full sentence"})
+	}
+	return issues
+}
+
+// renameFirstWord creates a suggested fix that replaces the first occurrence of word in the doc comment by name.
+func renameFirstWord(doc *ast.CommentGroup, word string, name string) []analysis.SuggestedFix {
+	for _, comment := range doc.List {
+		index := strings.Index(comment.Text, word)
+		if index < 0 {
+			continue
+		}
+		pos := comment.Slash + token.Pos(index)
+		return []analysis.SuggestedFix{{
+			Message: "Rename '" + word + "' to '" + name + "'",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     pos,
+				End:     pos + token.Pos(len(word)),
+				NewText: []byte(name),
+			}},
+		}}
+	}
+	return nil
+}
+
+// reportGodocIssues checks the doc comment of a symbol and reports all violations with the given rule ID.
+func reportGodocIssues(pass *analysis.Pass, pos token.Pos, ruleID string, doc *ast.CommentGroup, name string, allowArticle bool) {
+	for _, issue := range checkGodocConvention(doc, name, allowArticle) {
+		reportWithFixesf(pass, pos, ruleID, issue.fixes, "Doc comment of '%s' %s", name, issue.message)
+	}
+}
+
+// startsWithName determines if the first word of the comment is the name of the symbol, e.g. for `// Todo: ...` of a
+// function named `Todo`.
+func startsWithName(text string, name string) bool {
+	words := strings.Fields(text)
+	return len(words) > 0 && strings.TrimRight(words[0], ".,:;") == name
+}
@@ -0,0 +1,86 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/token"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGodocConvention(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"godoc"},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{RequireGodocConvention: true},
+					}),
+					"interfaces": NewChecker[InterfaceRuleResults](InterfaceRule[InterfaceRuleResults]{
+						Params: InterfaceRuleParameters{RequireGodocConvention: true},
+					}),
+					"structs": NewChecker[StructRuleResults](StructRule[StructRuleResults]{
+						Params: StructRuleParameters{RequireGodocConvention: true},
+					}),
+					"types": NewChecker[TypeRuleResults](TypeRule[TypeRuleResults]{
+						Params: TypeRuleParameters{RequireGodocConvention: true},
+					}),
+				},
+			},
+		},
+	}}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzers[0], "godoc")
+}
+
+func TestCheckGodocConvention(t *testing.T) {
+	tests := []struct {
+		name         string
+		comment      string
+		symbol       string
+		allowArticle bool
+		expected     []string
+	}{
+		{name: "Valid", comment: "// GetValue returns the value.", expected: nil},
+		{name: "Multiple paragraphs", comment: "// GetValue returns the value.\n//\n// It is cached", expected: nil},
+		{name: "Article", comment: "// A GetValue is a value.", allowArticle: true, expected: nil},
+		{name: "Article not allowed", comment: "// A GetValue is a value.", expected: []string{"should start with 'GetValue'"}},
+		{name: "Case mismatch", comment: "// getValue returns the value.", expected: []string{"should start with 'GetValue'"}},
+		{name: "Missing period", comment: "// GetValue returns the value", expected: []string{"should be a full sentence"}},
+		{name: "Both", comment: "// Returns the value", expected: []string{"should start with 'GetValue'", "should be a full sentence"}},
+		{name: "TODO", comment: "// TODO", expected: []string{"is a bare TODO"}},
+		{name: "TODO with description", comment: "// todo: describe GetValue.", expected: []string{"is a bare TODO"}},
+		{name: "TODO with author", comment: "// TODO(alice): describe GetValue.", expected: []string{"is a bare TODO"}},
+		{name: "TODO in sentence", comment: "// Todo returns the next todo item.", symbol: "Todo", expected: nil},
+		{name: "TODO as name", comment: "// Todo: returns the next todo item.", symbol: "Todo", expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &ast.CommentGroup{}
+			for i, line := range strings.Split(tt.comment, "\n") {
+				doc.List = append(doc.List, &ast.Comment{Slash: token.Pos(i*100 + 1), Text: line})
+			}
+			symbol := tt.symbol
+			if symbol == "" {
+				symbol = "GetValue"
+			}
+			var messages []string
+			for _, issue := range checkGodocConvention(doc, symbol, tt.allowArticle) {
+				messages = append(messages, issue.message)
+			}
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("Expected %v, but got %v", tt.expected, messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package qawaylinter
+
+import (
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGroupedTypeDeclarations(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"groups"},
+				Checks: map[string]Checker{
+					"interfaces": NewChecker[InterfaceRuleResults](InterfaceRule[InterfaceRuleResults]{
+						Params: InterfaceRuleParameters{RequireHeadlineComment: true, RequireMethodComment: true},
+					}),
+					"structs": NewChecker[StructRuleResults](StructRule[StructRuleResults]{
+						Params: StructRuleParameters{RequireHeadlineComment: true, RequireFieldComment: true},
+					}),
+				},
+			},
+		},
+	}}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "groups")
+}
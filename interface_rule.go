@@ -3,86 +3,225 @@ package qawaylinter
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
 	"golang.org/x/tools/go/analysis"
 )
 
+func init() {
+	RegisterJSONRule[InterfaceRuleResults, InterfaceRule[InterfaceRuleResults]]("interfaces")
+}
+
+type InterfaceFilters struct {
+	NameFilters
+	// MinMethods determines the minimum number of methods that an interface must have to be considered.
+	// Methods of embedded interfaces are not counted.
+	MinMethods int `json:"minMethods" minimum:"0"`
+}
+
 type InterfaceRuleParameters struct {
 	// RequireHeadlineComment determines if a comment must be placed on top of the interface.
 	RequireHeadlineComment bool `json:"requireHeadlineComment"`
 	// RequireMethodComment determines if a comment must be placed on top of each method in the interface.
 	RequireMethodComment bool `json:"requireMethodComment"`
+	// TrivialCommentThreshold determines the similarity between the interface name and its headline comment above
+	// which the comment is reported as trivial.
+	TrivialCommentThreshold float64 `json:"trivialCommentThreshold" minimum:"0" maximum:"1"`
+	// TrivialMethodCommentThreshold determines the similarity between a method name and its comment above which the
+	// comment is reported as trivial.
+	TrivialMethodCommentThreshold float64 `json:"trivialMethodCommentThreshold" minimum:"0" maximum:"1"`
+	// SimilarityMetric determines how the similarity for TrivialCommentThreshold and TrivialMethodCommentThreshold is
+	// measured: `levenshtein` (default), `jaccard` or `jaroWinkler`.
+	SimilarityMetric string `json:"similarityMetric"`
+	// RequireTypeParamMention determines if the headline comment of a generic interface must mention each of its type
+	// parameters, e.g. `T` for `type Container[T any] interface`.
+	RequireTypeParamMention bool `json:"requireTypeParamMention"`
+	// RequireConstraintComment determines if each union or approximation element of a constraint interface, such as
+	// `~int | ~string`, must have a comment explaining the constraint. The comment may be placed on top of the element
+	// or at the end of its line.
+	RequireConstraintComment bool `json:"requireConstraintComment"`
+	// RequireGodocConvention determines if the headline comment and the method comments must follow the Go doc comment
+	// conventions: they must start with the name of the interface (optionally preceded by `A`, `An` or `The`) or
+	// method, their first paragraph must be a full sentence and they must not be a bare TODO.
+	RequireGodocConvention bool `json:"requireGodocConvention"`
+	// IgnoreCommentPrefixes are prefixes of comment lines that are not counted as documentation, e.g. `TODO`.
+	// Directives, blank lines and license headers are never counted.
+	IgnoreCommentPrefixes []string `json:"ignoreCommentPrefixes"`
+	// CommentTemplate is the text/template of the doc comment that is suggested as fix for missing comments.
+	// The template receives CommentTemplateData. Defaults to `{{.Name}} ...`.
+	CommentTemplate string `json:"commentTemplate"`
+}
+
+// Validate ensures that the comment template can be parsed and that the similarity metric is known.
+func (p InterfaceRuleParameters) Validate() error {
+	if _, err := parseCommentTemplate(p.CommentTemplate); err != nil {
+		return &ConfigError{Path: "commentTemplate", Err: err}
+	}
+	if err := validateSimilarityMetric(p.SimilarityMetric); err != nil {
+		return &ConfigError{Path: "similarityMetric", Err: err}
+	}
+	return nil
 }
 
 type InterfaceRuleResults struct {
 	// Number of lines of comments in the headline of the interface.
 	HeadlineComments int
-	// Number of lines of comments in the body of the function. Key: function name, value: number of comment lines
-	FunctionComments map[string]int
+	// Position of the declaration, which is where a missing headline comment is inserted.
+	HeadlinePos token.Pos
+	// Headline comment of the interface.
+	HeadlineDoc *ast.CommentGroup
+	// Indicates the similarity between the interface name and the headline comments.
+	CommentSimilarity Similarity
+	// Comments on top of each method, ordered by their position in the source.
+	FunctionComments []MemberComments
+	// Comments of each union or approximation element, ordered by their position in the source.
+	// The name of an element is its source, e.g. `~int | ~string`.
+	ConstraintComments []MemberComments
+	// Type parameters of the interface that are not mentioned in the headline comment.
+	UnmentionedTypeParams []string
 }
 
 type InterfaceRule[ResultType InterfaceRuleResults] struct {
-	Params InterfaceRuleParameters `json:"params"`
+	Filters InterfaceFilters        `json:"filters"`
+	Params  InterfaceRuleParameters `json:"params"`
 }
 
 func (i InterfaceRule[ResultType]) IsApplicable(node ast.Node, pass *analysis.Pass, _ *ast.File) bool {
-	n, ok := node.(*ast.GenDecl)
-	if !ok {
-		return false
-	}
-	spec, ok := n.Specs[0].(*ast.TypeSpec)
+	spec, ok := node.(*ast.TypeSpec)
 	if !ok || spec.Type == nil {
 		return false
 	}
-	if _, ok := spec.Type.(*ast.InterfaceType); !ok {
+	iface, ok := spec.Type.(*ast.InterfaceType)
+	if !ok {
 		return false
 	}
 
-	return true
+	return i.Filters.matchesName(spec.Name.Name) && countMethods(iface) >= i.Filters.MinMethods
 }
 
-func (i InterfaceRule[ResultType]) Analyse(node ast.Node, pass *analysis.Pass, _ *ast.File) *InterfaceRuleResults {
-	typespec, ok := node.(*ast.GenDecl)
+func (i InterfaceRule[ResultType]) Analyse(node ast.Node, pass *analysis.Pass, file *ast.File) *InterfaceRuleResults {
+	typespec, ok := node.(*ast.TypeSpec)
 	if !ok {
 		return nil
 	}
 
-	typeComments := countHeadlineComments(typespec.Doc, pass.Fset)
+	doc, headlinePos := typeSpecDoc(typespec, file)
+	typeComments := countCommentLines(doc, i.Params.IgnoreCommentPrefixes)
 
-	var methodComments = make(map[string]int)
-	ast.Inspect(node, func(n ast.Node) bool {
-		if field, ok := n.(*ast.Field); ok {
-			if _, ok := field.Type.(*ast.FuncType); ok {
-				methodComments[field.Names[0].Name] = countHeadlineComments(field.Doc, pass.Fset)
+	var methodComments []MemberComments
+	var constraintComments []MemberComments
+	iface := typespec.Type.(*ast.InterfaceType)
+	previousEnd := iface.Methods.Opening
+	for _, field := range iface.Methods.List {
+		if _, ok := field.Type.(*ast.FuncType); ok {
+			methodComments = append(methodComments, MemberComments{
+				Name:              field.Names[0].Name,
+				Pos:               field.Names[0].Pos(),
+				Comments:          countCommentLines(field.Doc, i.Params.IgnoreCommentPrefixes),
+				Doc:               field.Doc,
+				CommentSimilarity: CommentSimilarity(field.Names[0].Name, field.Doc.Text(), i.Params.SimilarityMetric),
+			})
+		} else if isConstraintElement(field.Type) {
+			// the parser does not attach doc comments to type set elements.
+			doc := field.Doc
+			if doc == nil {
+				doc = leadingComments(file, pass.Fset, previousEnd, field.Pos())
 			}
+			constraintComments = append(constraintComments, MemberComments{
+				Name:             types.ExprString(field.Type),
+				Pos:              field.Type.Pos(),
+				Comments:         countCommentLines(doc, i.Params.IgnoreCommentPrefixes),
+				TrailingComments: countCommentLines(field.Comment, i.Params.IgnoreCommentPrefixes),
+			})
 		}
-		return true
-	})
+		previousEnd = field.End()
+	}
 
 	return &InterfaceRuleResults{
-		HeadlineComments: typeComments,
-		FunctionComments: methodComments,
+		HeadlineComments:      typeComments,
+		HeadlinePos:           headlinePos,
+		HeadlineDoc:           doc,
+		CommentSimilarity:     CommentSimilarity(typespec.Name.Name, doc.Text(), i.Params.SimilarityMetric),
+		FunctionComments:      methodComments,
+		ConstraintComments:    constraintComments,
+		UnmentionedTypeParams: unmentionedNames(doc.Text(), typeParamNames(typespec.TypeParams)),
 	}
 }
 
-func countHeadlineComments(comments *ast.CommentGroup, fset *token.FileSet) int {
-	if comments == nil {
-		return 0
+// isConstraintElement determines if an element of an interface restricts its type set,
+// i.e. it is a union such as `int | string` or an approximation such as `~int`.
+func isConstraintElement(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		return e.Op == token.OR
+	case *ast.UnaryExpr:
+		return e.Op == token.TILDE
+	case *ast.ParenExpr:
+		return isConstraintElement(e.X)
 	}
-	start := fset.Position(comments.Pos()).Line
-	end := fset.Position(comments.End()).Line
-	return end - start + 1
+	return false
+}
+
+// countMethods counts the methods declared in an interface, excluding embedded interfaces and type constraints.
+func countMethods(iface *ast.InterfaceType) int {
+	methods := 0
+	for _, field := range iface.Methods.List {
+		if _, ok := field.Type.(*ast.FuncType); ok {
+			methods++
+		}
+	}
+	return methods
 }
 
 func (i InterfaceRule[ResultType]) Apply(analysis *InterfaceRuleResults, node ast.Node, pass *analysis.Pass) {
 	if analysis == nil {
 		return
 	}
+	name := node.(*ast.TypeSpec).Name.Name
 	if analysis.HeadlineComments == 0 && i.Params.RequireHeadlineComment {
-		pass.Reportf(node.Pos(), "Interface '%s' is missing required headline comment", node.(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Name.Name)
+		fixes := suggestDocComment(pass, analysis.HeadlinePos, i.Params.CommentTemplate, CommentTemplateData{Name: name, Kind: "interface"})
+		reportWithFixesf(pass, node.Pos(), "interfaces/requireHeadlineComment", fixes, "Interface '%s' is missing required headline comment", name)
 	}
-	for name, comments := range analysis.FunctionComments {
-		if comments == 0 && i.Params.RequireMethodComment {
-			pass.Reportf(node.Pos(), "Method '%s' is missing required comment", name)
+	if analysis.HeadlineComments > 0 && i.Params.RequireTypeParamMention {
+		for _, typeParam := range analysis.UnmentionedTypeParams {
+			reportf(pass, node.Pos(), "interfaces/requireTypeParamMention", "Interface '%s' does not mention type parameter '%s' in its headline comment", name, typeParam)
 		}
 	}
+	if analysis.HeadlineComments > 0 && i.Params.RequireGodocConvention {
+		reportGodocIssues(pass, node.Pos(), "interfaces/requireGodocConvention", analysis.HeadlineDoc, name, true)
+	}
+	if i.Params.TrivialCommentThreshold > 0 && analysis.HeadlineComments > 0 && analysis.CommentSimilarity.Score > i.Params.TrivialCommentThreshold {
+		reportf(pass, node.Pos(), "interfaces/trivialCommentThreshold", "Interface '%s' has a trivial comment. Similarity to interface name: %.0f%%%s", name, analysis.CommentSimilarity.Score*100, explanationSuffix(analysis.CommentSimilarity))
+	}
+	// methods and constraints are reported in a single pass, so that the diagnostics are ordered by their position.
+	methods, constraints := analysis.FunctionComments, analysis.ConstraintComments
+	for len(methods) > 0 || len(constraints) > 0 {
+		if len(constraints) > 0 && (len(methods) == 0 || constraints[0].Pos < methods[0].Pos) {
+			i.applyConstraint(constraints[0], name, pass)
+			constraints = constraints[1:]
+		} else {
+			i.applyMethod(methods[0], pass)
+			methods = methods[1:]
+		}
+	}
+}
+
+// applyConstraint reports the findings of a union or approximation element of the interface with the given name.
+func (i InterfaceRule[ResultType]) applyConstraint(constraint MemberComments, name string, pass *analysis.Pass) {
+	if constraint.Comments == 0 && constraint.TrailingComments == 0 && i.Params.RequireConstraintComment {
+		reportf(pass, constraint.Pos, "interfaces/requireConstraintComment", "Constraint '%s' of interface '%s' is missing required comment", constraint.Name, name)
+	}
+}
+
+// applyMethod reports the findings of a method of the interface.
+func (i InterfaceRule[ResultType]) applyMethod(method MemberComments, pass *analysis.Pass) {
+	if method.Doc != nil && i.Params.RequireGodocConvention {
+		reportGodocIssues(pass, method.Pos, "interfaces/requireGodocConvention", method.Doc, method.Name, false)
+	}
+	if i.Params.TrivialMethodCommentThreshold > 0 && method.Comments > 0 && method.CommentSimilarity.Score > i.Params.TrivialMethodCommentThreshold {
+		reportf(pass, method.Pos, "interfaces/trivialMethodCommentThreshold", "Method '%s' has a trivial comment. Similarity to method name: %.0f%%%s", method.Name, method.CommentSimilarity.Score*100, explanationSuffix(method.CommentSimilarity))
+	}
+	if method.Comments == 0 && i.Params.RequireMethodComment {
+		fixes := suggestDocComment(pass, method.Pos, i.Params.CommentTemplate, CommentTemplateData{Name: method.Name, Kind: "interface method"})
+		reportWithFixesf(pass, method.Pos, "interfaces/requireMethodComment", fixes, "Method '%s' is missing required comment", method.Name)
+	}
 }
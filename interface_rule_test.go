@@ -1,9 +1,14 @@
 package qawaylinter
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -18,11 +23,13 @@ func TestInterfaceRule(t *testing.T) {
 		Targets: []Rules{
 			{
 				Packages: []string{"interfaces"},
-				InterfaceRule: &InterfaceRule[InterfaceRuleResults]{
-					Params: InterfaceRuleParameters{
-						RequireHeadlineComment: true,
-						RequireMethodComment:   true,
-					},
+				Checks: map[string]Checker{
+					"interfaces": NewChecker[InterfaceRuleResults](InterfaceRule[InterfaceRuleResults]{
+						Params: InterfaceRuleParameters{
+							RequireHeadlineComment: true,
+							RequireMethodComment:   true,
+						},
+					}),
 				},
 			},
 		},
@@ -30,3 +37,70 @@ func TestInterfaceRule(t *testing.T) {
 	analyzers, err := plugin.BuildAnalyzers()
 	analysistest.Run(t, testdata, analyzers[0], "interfaces")
 }
+
+func TestInterfaceTrivialComments(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"trivialinterfaces"},
+				Checks: map[string]Checker{
+					"interfaces": NewChecker[InterfaceRuleResults](InterfaceRule[InterfaceRuleResults]{
+						Params: InterfaceRuleParameters{
+							TrivialCommentThreshold:       0.5,
+							TrivialMethodCommentThreshold: 0.5,
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "trivialinterfaces")
+}
+
+func TestInterfaceDiagnosticsOrder(t *testing.T) {
+	fset := token.NewFileSet()
+	source := `package foo
+
+// Store stores values.
+type Store interface {
+	Get() string
+	~string | ~[]byte
+	// returns the value.
+	Set(value string)
+	Delete()
+}
+`
+	f, err := parser.ParseFile(fset, "foo.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+	spec := f.Decls[0].(*ast.GenDecl).Specs[0]
+
+	var reported []string
+	pass := &analysis.Pass{Fset: fset, Report: func(d analysis.Diagnostic) {
+		reported = append(reported, fset.Position(d.Pos).String()+": "+d.Message)
+	}}
+	rule := InterfaceRule[InterfaceRuleResults]{Params: InterfaceRuleParameters{
+		RequireMethodComment:     true,
+		RequireGodocConvention:   true,
+		RequireConstraintComment: true,
+	}}
+	rule.Apply(rule.Analyse(spec, pass, f), spec, pass)
+
+	expected := []string{
+		"foo.go:5:2: Method 'Get' is missing required comment",
+		"foo.go:6:2: Constraint '~string | ~[]byte' of interface 'Store' is missing required comment",
+		"foo.go:8:2: Doc comment of 'Set' should start with 'Set'",
+		"foo.go:9:2: Method 'Delete' is missing required comment",
+	}
+	if !slices.Equal(reported, expected) {
+		t.Errorf("Expected %v, but got %v", expected, reported)
+	}
+}
@@ -0,0 +1,24 @@
+// Command schemagen writes the JSON schema of the qawaylinter settings to a file.
+// It is executed via `go generate`.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	qawaylinter "github.com/qaware/qaway-linter"
+	"log"
+	"os"
+)
+
+func main() {
+	output := flag.String("o", "qaway.schema.json", "path of the generated schema")
+	flag.Parse()
+
+	data, err := json.MarshalIndent(qawaylinter.JSONSchema(), "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode schema: %v", err)
+	}
+	if err := os.WriteFile(*output, append(data, '\n'), 0o644); err != nil {
+		log.Fatalf("Failed to write schema: %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package qawaylinter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func init() {
+	RegisterJSONRule[LogMessageRuleResults, LogMessageRule[LogMessageRuleResults]]("logMessages")
+}
+
+// messageCases are the conventions that can be configured in the `messageCase` parameter of the log message rule.
+var messageCases = []string{"lower", "upper"}
+
+// trailingPunctuation are the characters a log message must not end with if ForbidTrailingPunctuation is set.
+const trailingPunctuation = ".!?:;"
+
+type LogMessageRuleParameters struct {
+	// RequireConstantMessage determines if the message of each logging call must be a constant string, so that log
+	// entries can be searched and grouped by their message. Variable parts belong into key-value arguments.
+	RequireConstantMessage bool `json:"requireConstantMessage"`
+	// ForbidFormatCalls determines if format-style logging calls such as `Printf` or `Infof` are reported in favor of
+	// calls with key-value arguments.
+	ForbidFormatCalls bool `json:"forbidFormatCalls"`
+	// RequireKeyValuePairs determines if the key-value arguments of calls such as `slog.Info` or zap's `Infow` must
+	// consist of pairs with string keys. Attributes such as `slog.String(...)` or `zap.String(...)` are accepted.
+	RequireKeyValuePairs bool `json:"requireKeyValuePairs"`
+	// MessageCase determines the case of the first letter of constant messages: `lower` or `upper`.
+	// The check is disabled if empty.
+	MessageCase string `json:"messageCase"`
+	// ForbidTrailingPunctuation determines if constant messages must not end with `.`, `!`, `?`, `:` or `;`.
+	ForbidTrailingPunctuation bool `json:"forbidTrailingPunctuation"`
+	// Logging configures how the logging calls are detected.
+	Logging LoggingDetection `json:"logging"`
+}
+
+// Validate ensures that the message case is known.
+func (p LogMessageRuleParameters) Validate() error {
+	if p.MessageCase != "" && !slices.Contains(messageCases, p.MessageCase) {
+		return &ConfigError{
+			Path: "messageCase",
+			Err:  fmt.Errorf("unknown case %q, expected one of %s", p.MessageCase, strings.Join(messageCases, ", ")),
+		}
+	}
+	return nil
+}
+
+// LogCall describes a single logging call.
+type LogCall struct {
+	// Name of the called function or method, e.g. `Infof`.
+	Name string
+	// Position of the call.
+	Pos token.Pos
+	// Position of the message argument. It is token.NoPos for calls without message, e.g. zerolog's `Send()` or
+	// `log.Print(err)`.
+	MessagePos token.Pos
+	// Message is the value of the message argument if it is a constant string.
+	Message string
+	// Indicates that the message argument is a constant string.
+	ConstantMessage bool
+	// Indicates that the call is format-style, i.e. its name ends with `f`.
+	Format bool
+	// Indicates that the call takes key-value arguments after the message.
+	KeyValues bool
+	// Indicates that the key-value arguments end with a key without value.
+	OddKeyValues bool
+	// Keys of the key-value arguments that are not strings, in their source form.
+	NonStringKeys []string
+}
+
+type LogMessageRuleResults struct {
+	// Logging calls of the function, including those in function literals, ordered by their position in the source.
+	Calls []LogCall
+}
+
+// LogMessageRule checks the logging calls of a function, e.g. that messages are constant and key-value arguments are
+// well-formed.
+type LogMessageRule[ResultType LogMessageRuleResults] struct {
+	Filters FunctionFilters          `json:"filters"`
+	Params  LogMessageRuleParameters `json:"params"`
+}
+
+func (l LogMessageRule[ResultType]) IsApplicable(node ast.Node, pass *analysis.Pass, _ *ast.File) bool {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil || !l.Filters.matchesDecl(funcDecl) {
+		return false
+	}
+	return l.Filters.MinLinesOfCode == 0 || countLinesInFunction(funcDecl, pass.Fset) >= l.Filters.MinLinesOfCode
+}
+
+func (l LogMessageRule[ResultType]) Analyse(node ast.Node, pass *analysis.Pass, _ *ast.File) *LogMessageRuleResults {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok {
+		return nil
+	}
+
+	results := &LogMessageRuleResults{}
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && l.Params.Logging.isLoggingCall(call, pass.TypesInfo) {
+			results.Calls = append(results.Calls, analyseLogCall(call, pass.TypesInfo))
+		}
+		return true
+	})
+	return results
+}
+
+func (l LogMessageRule[ResultType]) Apply(analysis *LogMessageRuleResults, _ ast.Node, pass *analysis.Pass) {
+	if analysis == nil {
+		return
+	}
+	for _, call := range analysis.Calls {
+		if l.Params.ForbidFormatCalls && call.Format {
+			reportf(pass, call.Pos, "logMessages/forbidFormatCalls", "Format-style log call '%s' should use key-value arguments", call.Name)
+		}
+		if l.Params.RequireKeyValuePairs && call.OddKeyValues {
+			reportf(pass, call.Pos, "logMessages/requireKeyValuePairs", "Log call '%s' has a key without value", call.Name)
+		}
+		if l.Params.RequireKeyValuePairs {
+			for _, key := range call.NonStringKeys {
+				reportf(pass, call.Pos, "logMessages/requireKeyValuePairs", "Key '%s' of log call '%s' is not a string", key, call.Name)
+			}
+		}
+		if call.MessagePos == token.NoPos {
+			continue
+		}
+		if !call.ConstantMessage {
+			if l.Params.RequireConstantMessage {
+				reportf(pass, call.MessagePos, "logMessages/requireConstantMessage", "Message of log call '%s' is not a constant string", call.Name)
+			}
+			continue
+		}
+		if issue := messageCaseIssue(call.Message, l.Params.MessageCase); issue != "" {
+			reportf(pass, call.MessagePos, "logMessages/messageCase", "Log message '%s' should start with %s letter", call.Message, issue)
+		}
+		if l.Params.ForbidTrailingPunctuation && strings.TrimRight(call.Message, trailingPunctuation) != call.Message {
+			reportf(pass, call.MessagePos, "logMessages/forbidTrailingPunctuation", "Log message '%s' should not end with punctuation", call.Message)
+		}
+	}
+}
+
+// RequiresTypesInfo determines if logging calls are detected using type information.
+func (l LogMessageRule[ResultType]) RequiresTypesInfo() bool {
+	return l.Params.Logging.TypeAware
+}
+
+// messageCaseIssue returns the expected case of the first letter of the message, `a lowercase` or `an uppercase`,
+// if it does not follow the convention. Messages that do not start with a letter are accepted.
+func messageCaseIssue(message string, messageCase string) string {
+	first, _ := utf8.DecodeRuneInString(message)
+	switch {
+	case messageCase == "lower" && unicode.IsUpper(first):
+		return "a lowercase"
+	case messageCase == "upper" && unicode.IsLower(first):
+		return "an uppercase"
+	}
+	return ""
+}
+
+// analyseLogCall determines the message and the key-value arguments of a logging call.
+func analyseLogCall(call *ast.CallExpr, info *types.Info) LogCall {
+	pkg, name := calledFunction(call, info)
+	logCall := LogCall{
+		Name:   name,
+		Pos:    call.Pos(),
+		Format: strings.HasSuffix(name, "f"),
+	}
+
+	index := messageIndex(call, pkg, name, info)
+	if index < 0 || index >= len(call.Args) {
+		return logCall
+	}
+	logCall.MessagePos = call.Args[index].Pos()
+	logCall.Message, logCall.ConstantMessage = constantString(call.Args[index], info)
+
+	if isKeyValueCall(pkg, name) {
+		logCall.KeyValues = true
+		logCall.OddKeyValues, logCall.NonStringKeys = checkKeyValues(call.Args[index+1:], info)
+	}
+	return logCall
+}
+
+// messageIndex returns the index of the message argument of a logging call or -1 if the call has no message.
+// With type information, the message is the first non-variadic string parameter, e.g. the third one of `slog.Log`.
+// Calls without such a parameter, e.g. `log.Print(err)` or `log.Println(x)`, have no message.
+// Otherwise, the context and level arguments of slog are skipped.
+func messageIndex(call *ast.CallExpr, pkg string, name string, info *types.Info) int {
+	if info != nil {
+		if signature, ok := info.TypeOf(call.Fun).(*types.Signature); ok {
+			params := signature.Params()
+			for i := 0; i < params.Len(); i++ {
+				if signature.Variadic() && i == params.Len()-1 {
+					break
+				}
+				if basic, ok := params.At(i).Type().(*types.Basic); ok && basic.Kind() == types.String {
+					return i
+				}
+			}
+			return -1
+		}
+	}
+
+	switch {
+	case (pkg == "slog" || pkg == "log/slog") && (name == "Log" || name == "LogAttrs"):
+		return 2
+	case strings.HasSuffix(name, "Context"):
+		return 1
+	}
+	return 0
+}
+
+// isKeyValueCall determines if the arguments after the message of a logging call are key-value pairs, which is the
+// case for the functions and methods of slog and the `...w` methods of zap's SugaredLogger.
+func isKeyValueCall(pkg string, name string) bool {
+	switch pkg {
+	case "log/slog", "slog":
+		return name != "LogAttrs"
+	case "go.uber.org/zap":
+		return strings.HasSuffix(name, "w")
+	}
+	return false
+}
+
+// checkKeyValues checks the key-value arguments of a logging call. Attributes such as `slog.String("key", value)`
+// take the place of a pair. It returns whether a key is missing its value and the keys that are not strings.
+// Without type information, only literals other than strings are reported as keys that are not strings.
+func checkKeyValues(args []ast.Expr, info *types.Info) (bool, []string) {
+	var nonStringKeys []string
+	for i := 0; i < len(args); i++ {
+		if isLogAttribute(args[i], info) {
+			continue
+		}
+		if !isStringExpr(args[i], info) {
+			nonStringKeys = append(nonStringKeys, types.ExprString(args[i]))
+		}
+		if i == len(args)-1 {
+			return true, nonStringKeys
+		}
+		i++
+	}
+	return false, nonStringKeys
+}
+
+// isLogAttribute determines if the argument is an attribute such as a `slog.Attr` or a `zap.Field`.
+// Without type information, any call is considered to create an attribute.
+func isLogAttribute(arg ast.Expr, info *types.Info) bool {
+	if info == nil {
+		_, ok := arg.(*ast.CallExpr)
+		return ok
+	}
+	named, ok := info.TypeOf(arg).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	switch named.Obj().Pkg().Path() {
+	case "log/slog":
+		return named.Obj().Name() == "Attr"
+	case "go.uber.org/zap", "go.uber.org/zap/zapcore":
+		return named.Obj().Name() == "Field"
+	}
+	return false
+}
+
+// isStringExpr determines if the expression is of type string.
+func isStringExpr(expr ast.Expr, info *types.Info) bool {
+	if info == nil {
+		lit, ok := expr.(*ast.BasicLit)
+		return !ok || lit.Kind == token.STRING
+	}
+	t := info.TypeOf(expr)
+	if t == nil {
+		return false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// constantString returns the value of the expression if it is a constant string.
+// Without type information, string literals and concatenations of them are considered.
+func constantString(expr ast.Expr, info *types.Info) (string, bool) {
+	if info != nil {
+		value := info.Types[expr].Value
+		if value == nil || value.Kind() != constant.String {
+			return "", false
+		}
+		return constant.StringVal(value), true
+	}
+
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.BinaryExpr:
+		x, xOk := constantString(e.X, nil)
+		y, yOk := constantString(e.Y, nil)
+		return x + y, e.Op == token.ADD && xOk && yOk
+	case *ast.ParenExpr:
+		return constantString(e.X, nil)
+	}
+	return "", false
+}
@@ -0,0 +1,84 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLogMessageRule(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"logmessages"},
+				Checks: map[string]Checker{
+					"logMessages": NewChecker[LogMessageRuleResults](LogMessageRule[LogMessageRuleResults]{
+						Params: LogMessageRuleParameters{
+							RequireConstantMessage:    true,
+							ForbidFormatCalls:         true,
+							RequireKeyValuePairs:      true,
+							MessageCase:               "lower",
+							ForbidTrailingPunctuation: true,
+							Logging:                   LoggingDetection{TypeAware: true},
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "logmessages")
+}
+
+func TestLogMessagesWithoutTypesInfo(t *testing.T) {
+	fset := token.NewFileSet()
+	source := `package foo
+
+func handle(ctx context.Context, name string) {
+	slog.InfoContext(ctx, "Started " + "service")
+	slog.Log(ctx, slog.LevelWarn, name, "port", 8080, 1)
+	logger.Infof("started %s", name)
+	catalog.Print("ignored")
+}
+`
+	f, err := parser.ParseFile(fset, "foo.go", source, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+	funcDecl := f.Decls[0].(*ast.FuncDecl)
+
+	var reported []string
+	pass := &analysis.Pass{Fset: fset, Report: func(d analysis.Diagnostic) {
+		reported = append(reported, fset.Position(d.Pos).String()+": "+d.Message)
+	}}
+	rule := LogMessageRule[LogMessageRuleResults]{Params: LogMessageRuleParameters{
+		RequireConstantMessage: true,
+		ForbidFormatCalls:      true,
+		RequireKeyValuePairs:   true,
+		MessageCase:            "lower",
+	}}
+	rule.Apply(rule.Analyse(funcDecl, pass, f), funcDecl, pass)
+
+	expected := []string{
+		"foo.go:4:24: Log message 'Started service' should start with a lowercase letter",
+		"foo.go:5:2: Log call 'Log' has a key without value",
+		"foo.go:5:2: Key '1' of log call 'Log' is not a string",
+		"foo.go:5:32: Message of log call 'Log' is not a constant string",
+		"foo.go:6:2: Format-style log call 'Infof' should use key-value arguments",
+	}
+	if !slices.Equal(reported, expected) {
+		t.Errorf("Expected %v, but got %v", expected, reported)
+	}
+}
@@ -0,0 +1,168 @@
+package qawaylinter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"golang.org/x/tools/go/types/typeutil"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// patterns for determining logger calls. the (?i) in the regex makes the regex case-insensitive.
+var loggerPattern = regexp.MustCompile("(?i)(log|logger)")
+
+// the method pattern also covers calls like Printf etc. as (?i)print also matches Printf.
+var loggerMethodPattern = regexp.MustCompile("(?i)(debug|info|warn|error|fatal|print|panic|trace|log)")
+
+// LoggerPackage describes the logging functions and methods declared in a package.
+type LoggerPackage struct {
+	// Path is the import path of the package, e.g. `log/slog`.
+	Path string
+	// Methods are the names of the functions and methods of the package that write a log entry.
+	Methods []string
+}
+
+// loggingPresets are the built-in logger packages that can be selected in the `presets` of LoggingDetection.
+// Methods that only build log entries, such as `logrus.WithField` or `zerolog.Logger.Info`, are not listed,
+// so that a chained call such as `log.Info().Msg("...")` is counted once.
+var loggingPresets = map[string][]LoggerPackage{
+	"log": {{
+		Path:    "log",
+		Methods: []string{"Fatal", "Fatalf", "Fatalln", "Output", "Panic", "Panicf", "Panicln", "Print", "Printf", "Println"},
+	}},
+	"slog": {{
+		Path: "log/slog",
+		Methods: []string{"Debug", "DebugContext", "Error", "ErrorContext", "Info", "InfoContext", "Log", "LogAttrs",
+			"Warn", "WarnContext"},
+	}},
+	"zap": {{
+		Path: "go.uber.org/zap",
+		Methods: []string{"DPanic", "DPanicf", "DPanicln", "DPanicw", "Debug", "Debugf", "Debugln", "Debugw", "Error",
+			"Errorf", "Errorln", "Errorw", "Fatal", "Fatalf", "Fatalln", "Fatalw", "Info", "Infof", "Infoln", "Infow",
+			"Log", "Logf", "Logln", "Logw", "Panic", "Panicf", "Panicln", "Panicw", "Warn", "Warnf", "Warnln", "Warnw"},
+	}},
+	"logrus": {{
+		Path: "github.com/sirupsen/logrus",
+		Methods: []string{"Debug", "Debugf", "Debugln", "Error", "Errorf", "Errorln", "Fatal", "Fatalf", "Fatalln",
+			"Info", "Infof", "Infoln", "Log", "Logf", "Logln", "Panic", "Panicf", "Panicln", "Print", "Printf",
+			"Println", "Trace", "Tracef", "Traceln", "Warn", "Warnf", "Warning", "Warningf", "Warningln", "Warnln"},
+	}},
+	"zerolog": {
+		{Path: "github.com/rs/zerolog", Methods: []string{"Msg", "MsgFunc", "Msgf", "Print", "Printf", "Println", "Send"}},
+		{Path: "github.com/rs/zerolog/log", Methods: []string{"Print", "Printf"}},
+	},
+}
+
+// LoggingDetection configures how logging statements are recognized.
+//
+// By default, logging is detected syntactically: a call such as `log.Warnf(...)` counts if the name of the identifier
+// contains `log` and the name of the method contains a log level, `print` or `log`. With TypeAware enabled, calls are
+// resolved using type information and count if the called function or method is declared in a logger package and is
+// one of its logging methods. This also detects `s.logger.Info(...)`, `zap.L().Info(...)` or loggers named `l`.
+type LoggingDetection struct {
+	// TypeAware enables the detection of logging calls by the package of the callee. It requires type information,
+	// which is loaded only if a rule enables this option.
+	TypeAware bool `json:"typeAware"`
+	// Presets are the built-in logger packages used in type-aware mode: `log`, `slog`, `zap`, `logrus` and `zerolog`.
+	// All presets are used if neither Presets nor Packages are configured.
+	Presets []string `json:"presets"`
+	// Packages are import paths of further logger packages used in type-aware mode, e.g. an internal logging package.
+	Packages []string `json:"packages"`
+	// Methods are the names of the logging functions and methods of Packages.
+	// If empty, functions and methods whose name contains a log level, `print` or `log` are logging calls.
+	Methods []string `json:"methods"`
+}
+
+// Validate ensures that only known presets are configured.
+func (d LoggingDetection) Validate() error {
+	for i, preset := range d.Presets {
+		if _, ok := loggingPresets[preset]; !ok {
+			return &ConfigError{
+				Path: "presets[" + strconv.Itoa(i) + "]",
+				Err:  fmt.Errorf("unknown preset %q, expected one of %s", preset, strings.Join(loggingPresetNames(), ", ")),
+			}
+		}
+	}
+	return nil
+}
+
+// loggingPresetNames returns the names of the built-in presets in alphabetical order.
+func loggingPresetNames() []string {
+	names := make([]string, 0, len(loggingPresets))
+	for name := range loggingPresets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// loggerPackages returns the logger packages selected by the presets and the configured packages.
+func (d LoggingDetection) loggerPackages() []LoggerPackage {
+	presets := d.Presets
+	if len(presets) == 0 && len(d.Packages) == 0 {
+		presets = loggingPresetNames()
+	}
+
+	var packages []LoggerPackage
+	for _, preset := range presets {
+		packages = append(packages, loggingPresets[preset]...)
+	}
+	for _, path := range d.Packages {
+		packages = append(packages, LoggerPackage{Path: path, Methods: d.Methods})
+	}
+	return packages
+}
+
+// isLoggingCall determines whether a node is a call of a logging function or method.
+// Calls are resolved with the given type information in type-aware mode. Without type information, logging calls are
+// detected syntactically, see isLoggingStatement.
+func (d LoggingDetection) isLoggingCall(n ast.Node, info *types.Info) bool {
+	if !d.TypeAware || info == nil {
+		return isLoggingStatement(n)
+	}
+
+	callExpr, ok := n.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := typeutil.Callee(info, callExpr).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+
+	for _, pkg := range d.loggerPackages() {
+		if pkg.Path != fn.Pkg().Path() {
+			continue
+		}
+		if (len(pkg.Methods) == 0 && loggerMethodPattern.MatchString(fn.Name())) || slices.Contains(pkg.Methods, fn.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLoggingStatements determines whether a given node contains a logging statement.
+// It first ensures that the node is of the correct types.
+func isLoggingStatement(n ast.Node) bool {
+	callExpr, ok := n.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	// selExpr.Sel.Name contains the method that is called on the logger, e.g. `Warnf`
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	// selExpr.X.Name contains struct that is called (e.g.) `log`
+	x, ok := selExpr.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	return loggerPattern.MatchString(x.Name) && loggerMethodPattern.MatchString(selExpr.Sel.Name)
+}
@@ -0,0 +1,104 @@
+package qawaylinter
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTypeAwareLogging(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"logging"},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{
+							MinLoggingDensity: 0.01,
+							Logging:           LoggingDetection{TypeAware: true},
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "logging")
+}
+
+func TestCustomLoggerPackages(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"customlogging"},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{
+							MinLoggingDensity: 0.01,
+							Logging: LoggingDetection{
+								TypeAware: true,
+								Packages:  []string{"catalog"},
+								Methods:   []string{"Info"},
+							},
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "customlogging")
+}
+
+func TestLoadMode(t *testing.T) {
+	syntaxOnly := FunctionRule[FunctionRuleResults]{Params: FunctionRuleParameters{MinLoggingDensity: 0.1}}
+	typeAware := FunctionRule[FunctionRuleResults]{Params: FunctionRuleParameters{Logging: LoggingDetection{TypeAware: true}}}
+
+	tests := []struct {
+		name     string
+		targets  []Rules
+		expected string
+	}{
+		{
+			name:     "Syntactic logging detection",
+			targets:  []Rules{{Checks: map[string]Checker{"functions": NewChecker[FunctionRuleResults](syntaxOnly)}}},
+			expected: register.LoadModeSyntax,
+		},
+		{
+			name: "Type-aware logging detection",
+			targets: []Rules{
+				{Checks: map[string]Checker{"functions": NewChecker[FunctionRuleResults](syntaxOnly)}},
+				{Checks: map[string]Checker{"functions": NewChecker[FunctionRuleResults](typeAware)}},
+			},
+			expected: register.LoadModeTypesInfo,
+		},
+		{
+			name:     "Type-aware logging detection in tests",
+			targets:  []Rules{{Tests: &Rules{Checks: map[string]Checker{"functions": NewChecker[FunctionRuleResults](typeAware)}}}},
+			expected: register.LoadModeTypesInfo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := AnalyzerPlugin{Settings: Settings{Targets: tt.targets}}
+			if mode := plugin.GetLoadMode(); mode != tt.expected {
+				t.Errorf("Expected load mode %q, but got %q", tt.expected, mode)
+			}
+		})
+	}
+}
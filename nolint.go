@@ -0,0 +1,187 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/token"
+	"golang.org/x/tools/go/analysis"
+	"math"
+	"strings"
+)
+
+// linterName is the name of the linter as used in nolint directives, e.g. `//nolint:qawaylinter/minLoggingDensity`.
+const linterName = "qawaylinter"
+
+// nolintDirective suppresses diagnostics of some or all rules within a range of lines of a file.
+type nolintDirective struct {
+	// Name of the file the directive applies to. Empty if the directive applies to all files (package level).
+	filename string
+	// First and last line the directive applies to. Package-level directives apply up to math.MaxInt, as they cover
+	// files of any length.
+	fromLine, toLine int
+	// IDs or partial IDs of the suppressed rules. Empty if all rules are suppressed.
+	rules []string
+}
+
+// nolintDirectives contains all nolint directives of a package that are relevant for this linter.
+type nolintDirectives []nolintDirective
+
+// collectNolintDirectives finds all nolint directives in the files of the package.
+// Directives are supported on four levels:
+//   - line: the directive is placed on the same line as the reported node.
+//   - declaration: the directive is part of the doc comment of a declaration, spec or field and applies to all of it.
+//   - file: the directive is placed above the package clause, but is not part of the package doc comment.
+//   - package: the directive is part of the package doc comment and applies to all files in the package.
+func collectNolintDirectives(pass *analysis.Pass) nolintDirectives {
+	var directives nolintDirectives
+
+	for _, f := range pass.Files {
+		tokenFile := pass.Fset.File(f.Pos())
+		filename := tokenFile.Name()
+
+		for _, group := range f.Comments {
+			rules, ok := parseNolintComments(group)
+			if !ok {
+				continue
+			}
+
+			switch {
+			case group == f.Doc:
+				directives = append(directives, nolintDirective{fromLine: 1, toLine: math.MaxInt, rules: rules})
+			case group.End() < f.Package:
+				directives = append(directives, nolintDirective{filename: filename, fromLine: 1, toLine: tokenFile.LineCount(), rules: rules})
+			default:
+				for _, comment := range group.List {
+					if rules, ok := parseNolintDirective(comment.Text); ok {
+						line := pass.Fset.Position(comment.Pos()).Line
+						directives = append(directives, nolintDirective{filename: filename, fromLine: line, toLine: line, rules: rules})
+					}
+				}
+			}
+		}
+
+		ast.Inspect(f, func(node ast.Node) bool {
+			doc := docComment(node)
+			if doc == nil {
+				return true
+			}
+			if rules, ok := parseNolintComments(doc); ok {
+				directives = append(directives, nolintDirective{
+					filename: filename,
+					fromLine: pass.Fset.Position(doc.Pos()).Line,
+					toLine:   pass.Fset.Position(node.End()).Line,
+					rules:    rules,
+				})
+			}
+			return true
+		})
+	}
+
+	return directives
+}
+
+// docComment returns the doc comment of declarations, specs and fields.
+// The package doc comment is not returned as it is handled separately.
+func docComment(node ast.Node) *ast.CommentGroup {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		return n.Doc
+	case *ast.GenDecl:
+		return n.Doc
+	case *ast.TypeSpec:
+		return n.Doc
+	case *ast.ValueSpec:
+		return n.Doc
+	case *ast.Field:
+		return n.Doc
+	}
+	return nil
+}
+
+// Suppresses checks if any directive suppresses the given diagnostic.
+func (d nolintDirectives) Suppresses(fset *token.FileSet, diagnostic analysis.Diagnostic) bool {
+	position := fset.Position(diagnostic.Pos)
+	for _, directive := range d {
+		if directive.filename != "" && directive.filename != position.Filename {
+			continue
+		}
+		if position.Line < directive.fromLine || position.Line > directive.toLine {
+			continue
+		}
+		if directive.matches(diagnostic.Category) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks if the directive applies to the rule with the given ID.
+// A rule is matched by its full ID (`functions/minLoggingDensity`), by its rule kind (`functions`)
+// or by the name of the check (`minLoggingDensity`).
+func (d nolintDirective) matches(ruleID string) bool {
+	if len(d.rules) == 0 {
+		return true
+	}
+	for _, rule := range d.rules {
+		if rule == ruleID || strings.HasPrefix(ruleID, rule+"/") || strings.HasSuffix(ruleID, "/"+rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNolintComments parses all directives of a comment group and merges the suppressed rules.
+func parseNolintComments(group *ast.CommentGroup) ([]string, bool) {
+	var rules []string
+	found := false
+	for _, comment := range group.List {
+		r, ok := parseNolintDirective(comment.Text)
+		if !ok {
+			continue
+		}
+		if len(r) == 0 {
+			return nil, true
+		}
+		found = true
+		rules = append(rules, r...)
+	}
+	return rules, found
+}
+
+// parseNolintDirective parses a single comment such as `//nolint:qawaylinter/minLoggingDensity,errcheck // reason`.
+// It returns the rules of this linter that are suppressed and whether the comment is a directive for this linter at all.
+// An empty list of rules indicates that all rules are suppressed, e.g. for `//nolint` or `//nolint:qawaylinter`.
+func parseNolintDirective(text string) ([]string, bool) {
+	if !isNolintDirective(text) {
+		return nil, false
+	}
+	text = strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(text, "//")), "nolint")
+	if text == "" || strings.HasPrefix(text, " ") {
+		// a bare `//nolint` suppresses all linters
+		return nil, true
+	}
+	if !strings.HasPrefix(text, ":") {
+		return nil, false
+	}
+
+	list, _, _ := strings.Cut(strings.TrimPrefix(text, ":"), " ")
+	var rules []string
+	found := false
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		switch {
+		case item == "all" || item == linterName:
+			return nil, true
+		case strings.HasPrefix(item, linterName+"/"):
+			found = true
+			rules = append(rules, strings.TrimPrefix(item, linterName+"/"))
+		}
+	}
+	return rules, found
+}
+
+// isNolintDirective checks if a comment is a nolint directive of any linter, e.g. `//nolint`, `//nolint:errcheck` or
+// `// nolint:qawaylinter`. The space after the comment marker is accepted as golangci-lint does.
+func isNolintDirective(text string) bool {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(strings.TrimPrefix(text, "//")), "nolint")
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == ':')
+}
@@ -0,0 +1,62 @@
+package qawaylinter
+
+import (
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNolintDirectives(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"nolint", "nolintpkg"},
+				Checks: map[string]Checker{
+					"functions": NewChecker[FunctionRuleResults](FunctionRule[FunctionRuleResults]{
+						Params: FunctionRuleParameters{
+							RequireHeadlineComment: true,
+							MinLoggingDensity:      0.1,
+						},
+					}),
+				},
+			},
+		},
+	}}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "nolint", "nolintpkg")
+}
+
+func TestParseNolintDirective(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expectedRules []string
+		expectedOk    bool
+	}{
+		{name: "Bare nolint", text: "//nolint", expectedRules: nil, expectedOk: true},
+		{name: "Linter", text: "//nolint:qawaylinter", expectedRules: nil, expectedOk: true},
+		{name: "All linters", text: "//nolint:all", expectedRules: nil, expectedOk: true},
+		{name: "Leading space", text: "// nolint:qawaylinter", expectedRules: nil, expectedOk: true},
+		{name: "Single rule", text: "//nolint:qawaylinter/minLoggingDensity", expectedRules: []string{"minLoggingDensity"}, expectedOk: true},
+		{name: "Multiple rules", text: "//nolint:errcheck,qawaylinter/functions,qawaylinter/structs/requireFieldComment // reason", expectedRules: []string{"functions", "structs/requireFieldComment"}, expectedOk: true},
+		{name: "Other linter", text: "//nolint:errcheck", expectedRules: nil, expectedOk: false},
+		{name: "No directive", text: "// nolintable code", expectedRules: nil, expectedOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, ok := parseNolintDirective(tt.text)
+			if ok != tt.expectedOk || !reflect.DeepEqual(rules, tt.expectedRules) {
+				t.Errorf("parseNolintDirective(%q) = %v, %v; want %v, %v", tt.text, rules, ok, tt.expectedRules, tt.expectedOk)
+			}
+		})
+	}
+}
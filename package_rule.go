@@ -0,0 +1,180 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/token"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/analysis"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The rule is registered as `packageDoc` as the key `packages` contains the package patterns of a target.
+func init() {
+	RegisterJSONRule[PackageRuleResults, PackageRule[PackageRuleResults]]("packageDoc")
+}
+
+// docFileName is the conventional name of the file containing the package comment.
+const docFileName = "doc.go"
+
+type PackageRuleParameters struct {
+	// RequireDocComment determines if a package comment must be placed on top of the package clause of a file.
+	RequireDocComment bool `json:"requireDocComment"`
+	// RequireDocFile determines if the package comment must be placed in a doc.go file.
+	RequireDocFile bool `json:"requireDocFile"`
+	// RequireSingleDocComment determines if the package comment must be placed in exactly one file.
+	RequireSingleDocComment bool `json:"requireSingleDocComment"`
+	// RequirePackagePrefix determines if the package comment must start with `Package <name>`.
+	// Main packages are not checked as their comment describes the command instead.
+	RequirePackagePrefix bool `json:"requirePackagePrefix"`
+	// MinTopLevelDocLines determines the minimum number of lines of the package comment of top-level packages,
+	// which are the package at the root of the module and its direct subpackages. If the driver does not provide the
+	// module, its path is read from the closest go.mod file. The check is skipped if no go.mod file is found.
+	MinTopLevelDocLines int `json:"minTopLevelDocLines" minimum:"0"`
+	// IgnoreCommentPrefixes are prefixes of comment lines that are not counted as documentation, e.g. `TODO`.
+	// Directives, blank lines and license headers are never counted.
+	IgnoreCommentPrefixes []string `json:"ignoreCommentPrefixes"`
+}
+
+// PackageDocComment is the package comment of a single file.
+type PackageDocComment struct {
+	// Name of the file containing the comment.
+	Filename string
+	// Position of the comment.
+	Pos token.Pos
+	// Text of the comment without comment markers.
+	Text string
+	// Number of lines of the comment.
+	Lines int
+}
+
+type PackageRuleResults struct {
+	// Name of the package.
+	Name string
+	// Position of the package clause of the first file, where package-level violations are reported.
+	Pos token.Pos
+	// Package comments of all files, ordered by the files of the package.
+	DocComments []PackageDocComment
+	// Indicates that the package is the root package of its module or a direct subpackage of it.
+	TopLevel bool
+}
+
+// DocLines returns the number of lines of all package comments.
+func (r PackageRuleResults) DocLines() int {
+	lines := 0
+	for _, doc := range r.DocComments {
+		lines += doc.Lines
+	}
+	return lines
+}
+
+// PackageRule checks the package as a whole. It does not check single nodes, see CheckPackage.
+type PackageRule[ResultType PackageRuleResults] struct {
+	Params PackageRuleParameters `json:"params"`
+}
+
+func (p PackageRule[ResultType]) IsApplicable(ast.Node, *analysis.Pass, *ast.File) bool {
+	return false
+}
+
+func (p PackageRule[ResultType]) Analyse(ast.Node, *analysis.Pass, *ast.File) *PackageRuleResults {
+	return nil
+}
+
+func (p PackageRule[ResultType]) Apply(*PackageRuleResults, ast.Node, *analysis.Pass) {}
+
+// CheckPackage checks the package comments of the given files.
+func (p PackageRule[ResultType]) CheckPackage(pass *analysis.Pass, files []*ast.File) {
+	if len(files) == 0 {
+		return
+	}
+	p.applyPackage(p.analysePackage(pass, files), pass)
+}
+
+// analysePackage collects the package comments of the given files.
+func (p PackageRule[ResultType]) analysePackage(pass *analysis.Pass, files []*ast.File) *PackageRuleResults {
+	var docComments []PackageDocComment
+	for _, f := range files {
+		if f.Doc == nil {
+			continue
+		}
+		docComments = append(docComments, PackageDocComment{
+			Filename: pass.Fset.Position(f.Package).Filename,
+			Pos:      f.Doc.Pos(),
+			Text:     f.Doc.Text(),
+			Lines:    countCommentLines(f.Doc, p.Params.IgnoreCommentPrefixes),
+		})
+	}
+
+	return &PackageRuleResults{
+		Name:        pass.Pkg.Name(),
+		Pos:         files[0].Package,
+		DocComments: docComments,
+		TopLevel:    isTopLevelPackage(pass.Pkg.Path(), modulePath(pass, files[0])),
+	}
+}
+
+// applyPackage reports the violations of the package comments.
+func (p PackageRule[ResultType]) applyPackage(analysis *PackageRuleResults, pass *analysis.Pass) {
+	name := analysis.Name
+
+	if len(analysis.DocComments) == 0 && p.Params.RequireDocComment {
+		reportf(pass, analysis.Pos, "packageDoc/requireDocComment", "Package '%s' is missing required package comment", name)
+	}
+	for i, doc := range analysis.DocComments {
+		if p.Params.RequireDocFile && filepath.Base(doc.Filename) != docFileName {
+			reportf(pass, doc.Pos, "packageDoc/requireDocFile", "Package comment of '%s' must be placed in %s", name, docFileName)
+		}
+		if p.Params.RequireSingleDocComment && i > 0 {
+			reportf(pass, doc.Pos, "packageDoc/requireSingleDocComment", "Package '%s' has conflicting package comments in %s and %s",
+				name, filepath.Base(analysis.DocComments[0].Filename), filepath.Base(doc.Filename))
+		}
+		if p.Params.RequirePackagePrefix && name != "main" && !hasPackagePrefix(doc.Text, name) {
+			reportf(pass, doc.Pos, "packageDoc/requirePackagePrefix", "Package comment of '%s' should start with 'Package %s'", name, name)
+		}
+	}
+	if analysis.TopLevel && analysis.DocLines() < p.Params.MinTopLevelDocLines {
+		reportf(pass, analysis.Pos, "packageDoc/minTopLevelDocLines", "Package '%s' has less than %d lines of package comment. Actual: %d", name, p.Params.MinTopLevelDocLines, analysis.DocLines())
+	}
+}
+
+// hasPackagePrefix checks if the package comment starts with `Package <name>` followed by a space or the end of the line.
+func hasPackagePrefix(text string, name string) bool {
+	rest, ok := strings.CutPrefix(text, "Package "+name)
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\n')
+}
+
+// isTopLevelPackage determines if the package is the root package of its module or a direct subpackage.
+// Packages are never top-level if the module is unknown.
+func isTopLevelPackage(pkgPath string, modulePath string) bool {
+	if modulePath == "" {
+		return false
+	}
+	if pkgPath == modulePath {
+		return true
+	}
+	rel, ok := strings.CutPrefix(pkgPath, modulePath+"/")
+	return ok && !strings.Contains(rel, "/")
+}
+
+// modulePath returns the path of the module of the package. If the driver does not provide the module, the path is
+// read from the closest go.mod file in the directory of the given file or above. An empty string is returned if there
+// is no go.mod file.
+func modulePath(pass *analysis.Pass, file *ast.File) string {
+	if pass.Module != nil && pass.Module.Path != "" {
+		return pass.Module.Path
+	}
+
+	dir := filepath.Dir(pass.Fset.Position(file.Package).Filename)
+	for {
+		if content, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+			return modfile.ModulePath(content)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
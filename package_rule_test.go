@@ -0,0 +1,122 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPackageRule(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"packagedoc", "packagenodoc"},
+				Checks: map[string]Checker{
+					"packageDoc": NewChecker[PackageRuleResults](PackageRule[PackageRuleResults]{
+						Params: PackageRuleParameters{
+							RequireDocComment:       true,
+							RequireDocFile:          true,
+							RequireSingleDocComment: true,
+							RequirePackagePrefix:    true,
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "packagedoc", "packagenodoc")
+}
+
+func TestPackageRuleMinTopLevelDocLines(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "doc.go", "// Package foo does things.\npackage foo\n", parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+
+	tests := []struct {
+		pkgPath  string
+		expected []string
+	}{
+		{pkgPath: "example.com/mod", expected: []string{"Package 'foo' has less than 3 lines of package comment. Actual: 1"}},
+		{pkgPath: "example.com/mod/foo", expected: []string{"Package 'foo' has less than 3 lines of package comment. Actual: 1"}},
+		{pkgPath: "example.com/mod/internal/foo", expected: nil},
+		{pkgPath: "example.com/module", expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pkgPath, func(t *testing.T) {
+			var reported []string
+			pass := &analysis.Pass{
+				Fset:   fset,
+				Pkg:    types.NewPackage(tt.pkgPath, "foo"),
+				Module: &analysis.Module{Path: "example.com/mod"},
+				Report: func(d analysis.Diagnostic) { reported = append(reported, d.Message) },
+			}
+			NewChecker[PackageRuleResults](PackageRule[PackageRuleResults]{
+				Params: PackageRuleParameters{MinTopLevelDocLines: 3},
+			}).(PackageChecker).CheckPackage(pass, []*ast.File{f})
+
+			if !slices.Equal(reported, tt.expected) {
+				t.Errorf("Expected %v, but got %v", tt.expected, reported)
+			}
+		})
+	}
+}
+
+func TestPackageRuleModuleFromGoMod(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/mod\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write go.mod: %s", err)
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join(dir, "foo", "doc.go"), "// Package foo does things.\npackage foo\n", parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+
+	var reported []string
+	pass := &analysis.Pass{
+		Fset:   fset,
+		Pkg:    types.NewPackage("example.com/mod/foo", "foo"),
+		Report: func(d analysis.Diagnostic) { reported = append(reported, d.Message) },
+	}
+	NewChecker[PackageRuleResults](PackageRule[PackageRuleResults]{
+		Params: PackageRuleParameters{MinTopLevelDocLines: 3},
+	}).(PackageChecker).CheckPackage(pass, []*ast.File{f})
+
+	expected := []string{"Package 'foo' has less than 3 lines of package comment. Actual: 1"}
+	if !slices.Equal(reported, expected) {
+		t.Errorf("Expected %v, but got %v", expected, reported)
+	}
+}
+
+func TestHasPackagePrefix(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{text: "Package foo does things.\n", expected: true},
+		{text: "Package foo\n", expected: true},
+		{text: "Package foobar does things.\n", expected: false},
+		{text: "Foo does things.\n", expected: false},
+	}
+	for _, tt := range tests {
+		if actual := hasPackagePrefix(tt.text, "foo"); actual != tt.expected {
+			t.Errorf("hasPackagePrefix(%q) = %t; want %t", tt.text, actual, tt.expected)
+		}
+	}
+}
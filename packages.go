@@ -0,0 +1,105 @@
+package qawaylinter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// regexpPrefix marks package patterns that are regular expressions, e.g. `re:^example\.com/.*/internal$`.
+const regexpPrefix = "re:"
+
+// patternKind determines how a package pattern is matched.
+// The order of the constants is used as tie-breaker for the specificity, i.e. a prefix is more specific than a regexp.
+type patternKind int
+
+const (
+	regexpPattern patternKind = iota
+	wildcardPattern
+	prefixPattern
+)
+
+// packagePattern is a compiled entry of `packages` or `excludePackages`.
+// Three kinds of patterns are supported:
+//   - prefixes such as `example.com/foo`, which match the package and all of its subpackages, but not `example.com/foobar`.
+//     As before the introduction of the other kinds, the empty prefix matches every package.
+//   - wildcards such as `example.com/svc/.../handler`, where `...` matches any string as in `go list`.
+//   - regular expressions prefixed with `re:`, which match if the expression matches any part of the package path.
+type packagePattern struct {
+	kind    patternKind
+	literal string
+	regexp  *regexp.Regexp
+}
+
+// compilePackagePattern parses a package pattern from the configuration.
+func compilePackagePattern(pattern string) (packagePattern, error) {
+	switch {
+	case strings.HasPrefix(pattern, regexpPrefix):
+		expr, err := regexp.Compile(strings.TrimPrefix(pattern, regexpPrefix))
+		if err != nil {
+			return packagePattern{}, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return packagePattern{kind: regexpPattern, regexp: expr}, nil
+	case strings.Contains(pattern, "..."):
+		expr := regexp.QuoteMeta(pattern)
+		// as in `go list`, a trailing `/...` also matches the package itself.
+		if strings.HasSuffix(expr, `/\.\.\.`) {
+			expr = strings.TrimSuffix(expr, `/\.\.\.`) + `(/\.\.\.)?`
+		}
+		expr = "^" + strings.ReplaceAll(expr, `\.\.\.`, `.*`) + "$"
+		return packagePattern{kind: wildcardPattern, literal: pattern, regexp: regexp.MustCompile(expr)}, nil
+	default:
+		return packagePattern{kind: prefixPattern, literal: strings.TrimSuffix(pattern, "/")}, nil
+	}
+}
+
+// matches checks if the pattern matches the given package path.
+func (p packagePattern) matches(pkgPath string) bool {
+	if p.kind == prefixPattern {
+		return p.literal == "" || pkgPath == p.literal || strings.HasPrefix(pkgPath, p.literal+"/")
+	}
+	return p.regexp.MatchString(pkgPath)
+}
+
+// specificity determines how specific a pattern is. Patterns with a higher specificity take precedence.
+// The specificity is the number of leading path segments that are matched literally, e.g. 3 for `example.com/foo/bar`
+// and 2 for `example.com/svc/.../handler`. For regular expressions, the literal prefix of the expression is used.
+// A regular expression that is a complete literal apart from its anchors, e.g. `^example\.com/foo/bar$`, counts with all
+// of its segments like a prefix.
+// If two patterns have the same number of literal segments, prefixes are more specific than wildcards,
+// which are more specific than regular expressions.
+func (p packagePattern) specificity() (int, patternKind) {
+	literal := p.literal
+	switch p.kind {
+	case wildcardPattern:
+		literal, _, _ = strings.Cut(literal, "...")
+		literal = literal[:strings.LastIndex(literal, "/")+1]
+	case regexpPattern:
+		expr := strings.TrimPrefix(p.regexp.String(), "^")
+		if !strings.HasSuffix(expr, `\$`) {
+			expr = strings.TrimSuffix(expr, "$")
+		}
+		complete := false
+		if prefixExpr, err := regexp.Compile(expr); err == nil {
+			literal, complete = prefixExpr.LiteralPrefix()
+		}
+		if !complete {
+			literal = literal[:strings.LastIndex(literal, "/")+1]
+		}
+	}
+
+	if literal == "" {
+		return 0, p.kind
+	}
+	return len(strings.Split(strings.TrimSuffix(literal, "/"), "/")), p.kind
+}
+
+// moreSpecificThan compares the specificity of two patterns.
+func (p packagePattern) moreSpecificThan(other packagePattern) bool {
+	segments, kind := p.specificity()
+	otherSegments, otherKind := other.specificity()
+	if segments != otherSegments {
+		return segments > otherSegments
+	}
+	return kind > otherKind
+}
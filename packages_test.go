@@ -0,0 +1,140 @@
+package qawaylinter
+
+import (
+	"go/types"
+	"testing"
+)
+
+func TestPackagePatternMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		pkg      string
+		expected bool
+	}{
+		{name: "Prefix matches package", pattern: "example.com/foo", pkg: "example.com/foo", expected: true},
+		{name: "Prefix matches subpackage", pattern: "example.com/foo", pkg: "example.com/foo/bar", expected: true},
+		{name: "Prefix respects segments", pattern: "example.com/foo", pkg: "example.com/foobar", expected: false},
+		{name: "Wildcard in the middle", pattern: "example.com/svc/.../handler", pkg: "example.com/svc/a/b/handler", expected: true},
+		{name: "Wildcard requires suffix", pattern: "example.com/svc/.../handler", pkg: "example.com/svc/a/handler/v2", expected: false},
+		{name: "Trailing wildcard matches package", pattern: "example.com/foo/...", pkg: "example.com/foo", expected: true},
+		{name: "Trailing wildcard respects segments", pattern: "example.com/foo/...", pkg: "example.com/foobar", expected: false},
+		{name: "Regular expression", pattern: `re:^example\.com/.*/mocks$`, pkg: "example.com/foo/mocks", expected: true},
+		{name: "Regular expression does not match", pattern: `re:^example\.com/.*/mocks$`, pkg: "example.com/foo/mocks/bar", expected: false},
+		{name: "Empty prefix matches every package", pattern: "", pkg: "example.com/foo", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := compilePackagePattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("Failed to compile pattern: %s", err)
+			}
+			if actual := pattern.matches(tt.pkg); actual != tt.expected {
+				t.Errorf("%q matches %q = %v; want %v", tt.pattern, tt.pkg, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPackagePatternSpecificity(t *testing.T) {
+	tests := []struct {
+		name         string
+		moreSpecific string
+		lessSpecific string
+	}{
+		{name: "Subpackage", moreSpecific: "example.com/foo/bar", lessSpecific: "example.com/foo"},
+		{name: "Literal segments of wildcard", moreSpecific: "example.com/foo/bar/.../handler", lessSpecific: "example.com/foo"},
+		{name: "Prefix over wildcard", moreSpecific: "example.com/foo", lessSpecific: "example.com/foo/..."},
+		{name: "Wildcard over regular expression", moreSpecific: "example.com/foo/...", lessSpecific: `re:^example\.com/foo/.*`},
+		{name: "Literal prefix of regular expression", moreSpecific: `re:^example\.com/foo/bar/.*`, lessSpecific: "example.com/foo"},
+		{name: "Literal regular expression", moreSpecific: `re:^example\.com/foo/bar$`, lessSpecific: "example.com/foo"},
+		{name: "Prefix over empty prefix", moreSpecific: "example.com", lessSpecific: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			more, _ := compilePackagePattern(tt.moreSpecific)
+			less, _ := compilePackagePattern(tt.lessSpecific)
+			if !more.moreSpecificThan(less) || less.moreSpecificThan(more) {
+				t.Errorf("Expected %q to be more specific than %q", tt.moreSpecific, tt.lessSpecific)
+			}
+		})
+	}
+}
+
+func TestMatchesPackageExclusions(t *testing.T) {
+	target := Rules{
+		Packages:        []string{"example.com/foo"},
+		ExcludePackages: []string{"example.com/foo/internal/mocks"},
+	}
+
+	if ok, _ := target.MatchesPackage(types.NewPackage("example.com/foo/bar", "bar")); !ok {
+		t.Errorf("Expected example.com/foo/bar to match")
+	}
+	if ok, _ := target.MatchesPackage(types.NewPackage("example.com/foo/internal/mocks/db", "db")); ok {
+		t.Errorf("Expected example.com/foo/internal/mocks/db to be excluded")
+	}
+}
+
+func TestDecodeInvalidPackagePattern(t *testing.T) {
+	_, err := DecodeSettings(map[string]any{
+		"rules": []any{map[string]any{"packages": []any{"example.com/foo", "re:("}}},
+	})
+	expected := "rules[0].packages[1]: invalid regular expression: error parsing regexp: missing closing ): `(`"
+	if err == nil || err.Error() != expected {
+		t.Errorf("DecodeSettings() error = %v; want %s", err, expected)
+	}
+}
+
+func TestBuildAnalyzersInvalidPackagePattern(t *testing.T) {
+	plugin := AnalyzerPlugin{Settings: Settings{Targets: []Rules{
+		{Packages: []string{"example.com/foo"}},
+		{Packages: []string{"example.com/bar"}, ExcludePackages: []string{"re:("}},
+	}}}
+	_, err := plugin.BuildAnalyzers()
+	expected := "rules[1].excludePackages[0]: invalid regular expression: error parsing regexp: missing closing ): `(`"
+	if err == nil || err.Error() != expected {
+		t.Errorf("BuildAnalyzers() error = %v; want %s", err, expected)
+	}
+}
+
+func TestDecodeCompilesPackagePatterns(t *testing.T) {
+	settings, err := DecodeSettings(map[string]any{
+		"rules": []any{map[string]any{"packages": []any{"example.com/foo"}, "excludePackages": []any{"re:/mocks$"}}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to decode settings: %s", err)
+	}
+	if target := settings.Targets[0]; len(target.packagePatterns) != 1 || len(target.excludePatterns) != 1 {
+		t.Errorf("Expected package patterns to be compiled, but got %v and %v", target.packagePatterns, target.excludePatterns)
+	}
+}
+
+func TestGetMatchingTargetPatternKinds(t *testing.T) {
+	settings := Settings{Targets: []Rules{
+		{Packages: []string{""}},
+		{Packages: []string{"example.com/foo"}, Inherit: new(bool)},
+		{Packages: []string{`re:^example\.com/foo/bar$`}, Inherit: new(bool)},
+	}}
+
+	tests := []struct {
+		pkg      string
+		expected string
+	}{
+		{pkg: "other.com/baz", expected: ""},
+		{pkg: "example.com/foo/baz", expected: "example.com/foo"},
+		{pkg: "example.com/foo/bar", expected: `re:^example\.com/foo/bar$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			target, err := settings.GetMatchingTarget(types.NewPackage(tt.pkg, "pkg"))
+			if err != nil {
+				t.Fatalf("Failed to get matching target: %s", err)
+			}
+			if target == nil || target.Packages[0] != tt.expected {
+				t.Errorf("Expected target %q for %s, but got %v", tt.expected, tt.pkg, target)
+			}
+		})
+	}
+}
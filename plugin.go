@@ -8,8 +8,8 @@ func init() {
 
 func New(conf any) (register.LinterPlugin, error) {
 	// The configuration type will be map[string]any or []interface, it depends on your configuration.
-	// You can use https://github.com/go-viper/mapstructure to convert map to struct.
-	settings, err := register.DecodeSettings[Settings](conf)
+	// DecodeSettings rejects unknown keys and invalid values instead of silently ignoring them.
+	settings, err := DecodeSettings(conf)
 	if err != nil {
 		return nil, err
 	}
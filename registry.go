@@ -0,0 +1,145 @@
+package qawaylinter
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"golang.org/x/tools/go/analysis"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Checker is the non-generic counterpart of the Rule interface.
+// The analyzer only works with Checkers, which allows all rule kinds to be stored and executed in a single list.
+// Rules implementing the generic Rule interface can be converted into a Checker using NewChecker.
+type Checker interface {
+	// Check runs the rule against the given node and reports violations to the pass.
+	Check(node ast.Node, pass *analysis.Pass, file *ast.File)
+}
+
+// PackageChecker is implemented by Checkers and Rules that check a package as a whole, e.g. its package comment.
+// CheckPackage is called once per package after all of its files have been inspected.
+type PackageChecker interface {
+	// CheckPackage runs the check against the files of the package that the target applies to.
+	// Test files are not included.
+	CheckPackage(pass *analysis.Pass, files []*ast.File)
+}
+
+// TypesInfoRequirer is implemented by Checkers and Rules that need type information, i.e. `pass.TypesInfo`.
+// Type information is only loaded if at least one configured rule requires it, as loading it is considerably slower.
+type TypesInfoRequirer interface {
+	// RequiresTypesInfo determines if the rule needs type information with its current configuration.
+	RequiresTypesInfo() bool
+}
+
+// RuleDecoder decodes the configuration of a single rule kind into a Checker.
+// The configuration is passed as raw JSON as it is found below the registered key of a target.
+type RuleDecoder func(config json.RawMessage) (Checker, error)
+
+// registeredRule is an entry of the rule registry.
+type registeredRule struct {
+	decoder RuleDecoder
+	// configType is the Go type the configuration is decoded into. It is used to generate the JSON schema.
+	// Nil if the rule was registered with a custom decoder.
+	configType reflect.Type
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]registeredRule)
+)
+
+// RegisterRule makes a rule kind available under the given configuration key.
+// It is intended to be called from the init function of the package implementing the rule,
+// which also allows packages outside of this module to add their own rules.
+// RegisterRule panics if the key is empty or has already been registered.
+func RegisterRule(key string, decoder RuleDecoder) {
+	addToRegistry(key, registeredRule{decoder: decoder})
+}
+
+// RegisterJSONRule registers a rule kind whose configuration is decoded into RuleType using JSONRuleDecoder.
+// In contrast to RegisterRule, the configuration of the rule is also part of the generated JSON schema.
+func RegisterJSONRule[ResultType any, RuleType Rule[ResultType]](key string) {
+	addToRegistry(key, registeredRule{
+		decoder:    JSONRuleDecoder[ResultType, RuleType](),
+		configType: reflect.TypeFor[RuleType](),
+	})
+}
+
+func addToRegistry(key string, rule registeredRule) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, reserved := jsonFields(reflect.TypeFor[Rules]())[key]; reserved || key == "" {
+		panic(fmt.Sprintf("qawaylinter: invalid rule key %q", key))
+	}
+	if _, exists := registry[key]; exists {
+		panic(fmt.Sprintf("qawaylinter: rule %q registered twice", key))
+	}
+	registry[key] = rule
+}
+
+// RegisteredRules returns the keys of all registered rule kinds in alphabetical order.
+func RegisteredRules() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	keys := make([]string, 0, len(registry))
+	for key := range registry {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func lookupRule(key string) (registeredRule, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	rule, ok := registry[key]
+	return rule, ok
+}
+
+// NewChecker converts a generic Rule into a Checker.
+// The resulting Checker calls IsApplicable, Analyse and Apply in this order.
+func NewChecker[ResultType any](rule Rule[ResultType]) Checker {
+	return ruleChecker[ResultType]{rule: rule}
+}
+
+type ruleChecker[ResultType any] struct {
+	rule Rule[ResultType]
+}
+
+// RequiresTypesInfo delegates to the rule if it implements TypesInfoRequirer.
+func (c ruleChecker[ResultType]) RequiresTypesInfo() bool {
+	requirer, ok := c.rule.(TypesInfoRequirer)
+	return ok && requirer.RequiresTypesInfo()
+}
+
+// CheckPackage delegates to the rule if it implements PackageChecker.
+func (c ruleChecker[ResultType]) CheckPackage(pass *analysis.Pass, files []*ast.File) {
+	if packageCheck, ok := c.rule.(PackageChecker); ok {
+		packageCheck.CheckPackage(pass, files)
+	}
+}
+
+func (c ruleChecker[ResultType]) Check(node ast.Node, pass *analysis.Pass, file *ast.File) {
+	if !c.rule.IsApplicable(node, pass, file) {
+		return
+	}
+	results := c.rule.Analyse(node, pass, file)
+	c.rule.Apply(results, node, pass)
+}
+
+// JSONRuleDecoder returns a RuleDecoder that decodes the configuration into a RuleType and wraps it using NewChecker.
+// The configuration is decoded strictly (see DecodeStrict), i.e. unknown keys and values out of range are rejected.
+func JSONRuleDecoder[ResultType any, RuleType Rule[ResultType]]() RuleDecoder {
+	return func(config json.RawMessage) (Checker, error) {
+		var rule RuleType
+		if err := DecodeStrict(config, &rule); err != nil {
+			return nil, err
+		}
+		return NewChecker[ResultType](rule), nil
+	}
+}
@@ -0,0 +1,93 @@
+package qawaylinter
+
+import (
+	"encoding/json"
+	"go/ast"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// emptyFunctionRule is a minimal rule as it could be registered by a package outside of this module.
+type emptyFunctionRule struct {
+	Message string `json:"message"`
+}
+
+func (r emptyFunctionRule) Check(node ast.Node, pass *analysis.Pass, file *ast.File) {
+	// rules that do not implement PackageChecker are only called with nodes of a file.
+	if file == nil {
+		pass.Reportf(node.Pos(), "unexpected node %T without file", node)
+		return
+	}
+	if funcDecl, ok := node.(*ast.FuncDecl); ok && len(funcDecl.Body.List) == 0 {
+		pass.Reportf(node.Pos(), "%s: %s", r.Message, funcDecl.Name.Name)
+	}
+}
+
+func init() {
+	RegisterRule("test/emptyFunctions", func(config json.RawMessage) (Checker, error) {
+		var rule emptyFunctionRule
+		err := json.Unmarshal(config, &rule)
+		return rule, err
+	})
+}
+
+func TestDecodeRegisteredRules(t *testing.T) {
+	plugin, err := New(map[string]any{
+		"rules": []any{
+			map[string]any{
+				"packages":            []any{"registry"},
+				"functions":           map[string]any{"params": map[string]any{"requireHeadlineComment": true}},
+				"test/emptyFunctions": map[string]any{"message": "empty function"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to decode settings: %s", err)
+	}
+
+	checks := plugin.(*AnalyzerPlugin).Settings.Targets[0].Checks
+	if _, ok := checks["functions"].(ruleChecker[FunctionRuleResults]); !ok {
+		t.Errorf("Expected functions rule to be decoded, but got %v", checks["functions"])
+	}
+	if rule, ok := checks["test/emptyFunctions"].(emptyFunctionRule); !ok || rule.Message != "empty function" {
+		t.Errorf("Expected custom rule to be decoded, but got %v", checks["test/emptyFunctions"])
+	}
+}
+
+func TestDecodeUnknownRule(t *testing.T) {
+	_, err := New(map[string]any{
+		"rules": []any{
+			map[string]any{
+				"packages": []any{"registry"},
+				"function": map[string]any{},
+			},
+		},
+	})
+	if err == nil {
+		t.Errorf("Expected error for unknown rule, but got none")
+	}
+}
+
+func TestRegisteredRule(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"registry"},
+				Checks: map[string]Checker{
+					"test/emptyFunctions": emptyFunctionRule{Message: "empty function"},
+				},
+			},
+		},
+	}}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "registry")
+}
@@ -1,8 +1,16 @@
 package qawaylinter
 
 import (
+	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
 )
 
 // Rule is an interface that is used to define checks for different types of nodes in the AST.
@@ -15,3 +23,184 @@ type Rule[ResultType any] interface {
 	// It returns an error if the analysis results do not match the input parameters.
 	Apply(analysis *ResultType, node ast.Node, pass *analysis.Pass)
 }
+
+// typeSpecDoc returns the doc comment of a type spec and the position at which a missing doc comment is inserted.
+// The doc comment of the spec itself takes precedence. If the declaration only contains a single spec, e.g. for
+// `type A struct{}`, the doc comment of the declaration is used instead. This also applies to types declared in
+// function bodies.
+func typeSpecDoc(spec *ast.TypeSpec, file *ast.File) (*ast.CommentGroup, token.Pos) {
+	genDecl := enclosingGenDecl(file, spec)
+	if genDecl == nil || len(genDecl.Specs) != 1 {
+		return spec.Doc, spec.Pos()
+	}
+	if spec.Doc != nil {
+		return spec.Doc, genDecl.Pos()
+	}
+	return genDecl.Doc, genDecl.Pos()
+}
+
+// enclosingGenDecl returns the declaration that contains the spec, which may be declared in a function body.
+// Only the top-level declaration containing the spec and the nodes enclosing the spec are visited.
+func enclosingGenDecl(file *ast.File, spec ast.Spec) *ast.GenDecl {
+	var enclosing *ast.GenDecl
+	ast.Inspect(topLevelDecl(file, spec), func(n ast.Node) bool {
+		if n == nil || enclosing != nil || n.End() < spec.Pos() || n.Pos() > spec.End() {
+			return false
+		}
+		if genDecl, ok := n.(*ast.GenDecl); ok && slices.Contains(genDecl.Specs, spec) {
+			enclosing = genDecl
+		}
+		return enclosing == nil
+	})
+	return enclosing
+}
+
+// topLevelDecl returns the declaration of the file that contains the node or nil if there is none.
+// As the declarations of a file are ordered by their position, a binary search is used.
+func topLevelDecl(file *ast.File, node ast.Node) ast.Decl {
+	if file == nil {
+		return nil
+	}
+	i := sort.Search(len(file.Decls), func(i int) bool {
+		return file.Decls[i].End() >= node.End()
+	})
+	if i < len(file.Decls) && file.Decls[i].Pos() <= node.Pos() {
+		return file.Decls[i]
+	}
+	return nil
+}
+
+// MemberComments describes the comments of a member of a type, e.g. an interface method or a struct field.
+type MemberComments struct {
+	// Name of the member.
+	Name string
+	// Position of the identifier of the member.
+	Pos token.Pos
+	// Number of lines of comments on top of the member.
+	Comments int
+	// Number of lines of comments at the end of the line of the member.
+	TrailingComments int
+	// Embedded indicates that the member is an embedded type. The name of the member is the name of the type.
+	Embedded bool
+	// Doc comment on top of the member. It is nil for members sharing their comment with others, e.g. in `A, B int`.
+	Doc *ast.CommentGroup
+	// Similarity between the name of the member and its comment. It is zero for members without a comment of their own.
+	CommentSimilarity Similarity
+}
+
+// reportf reports a violation of the check with the given rule ID.
+// Rule IDs consist of the registered rule key and the name of the check, e.g. `functions/requireHeadlineComment`.
+// The ID is used as category of the diagnostic, which allows to exclude it in golangci-lint and via nolint directives.
+func reportf(pass *analysis.Pass, pos token.Pos, ruleID string, format string, args ...any) {
+	reportWithFixesf(pass, pos, ruleID, nil, format, args...)
+}
+
+// reportWithFixesf reports a violation like reportf, but also attaches suggested fixes to the diagnostic.
+func reportWithFixesf(pass *analysis.Pass, pos token.Pos, ruleID string, fixes []analysis.SuggestedFix, format string, args ...any) {
+	pass.Report(analysis.Diagnostic{
+		Pos:            pos,
+		Category:       ruleID,
+		Message:        fmt.Sprintf(format, args...),
+		SuggestedFixes: fixes,
+	})
+}
+
+// reportRangef reports a violation of the check with the given rule ID that spans from pos to end.
+func reportRangef(pass *analysis.Pass, pos token.Pos, end token.Pos, ruleID string, format string, args ...any) {
+	pass.Report(analysis.Diagnostic{
+		Pos:      pos,
+		End:      end,
+		Category: ruleID,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// typeName returns the name of a type expression without pointers, packages and type arguments,
+// e.g. `Config` for `*config.Config` or `List` for `List[T]`.
+func typeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return typeName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return typeName(e.X)
+	case *ast.IndexListExpr:
+		return typeName(e.X)
+	}
+	return ""
+}
+
+// typeParamNames returns the names of the type parameters of a declaration, e.g. `T` and `U` for `[T any, U any]`.
+func typeParamNames(typeParams *ast.FieldList) []string {
+	if typeParams == nil {
+		return nil
+	}
+	var names []string
+	for _, field := range typeParams.List {
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// unmentionedNames returns the names that do not occur as a separate word in the text of a comment.
+// Words consist of letters, digits and underscores like Go identifiers, so `T` is not mentioned by `Type` or `T_1`.
+func unmentionedNames(text string, names []string) []string {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}) {
+		words[word] = true
+	}
+
+	var unmentioned []string
+	for _, name := range names {
+		if !words[name] {
+			unmentioned = append(unmentioned, name)
+		}
+	}
+	return unmentioned
+}
+
+// leadingComments returns the comment group in the lines directly above pos, but below the line of after.
+// It is used for nodes the parser does not attach a doc comment to, e.g. the type set elements of interfaces.
+func leadingComments(file *ast.File, fset *token.FileSet, after token.Pos, pos token.Pos) *ast.CommentGroup {
+	if file == nil {
+		return nil
+	}
+	line := fset.Position(pos).Line
+	afterLine := fset.Position(after).Line
+	for _, group := range file.Comments {
+		if fset.Position(group.End()).Line == line-1 && fset.Position(group.Pos()).Line > afterLine {
+			return group
+		}
+	}
+	return nil
+}
+
+// calledFunction returns the package and the name of the called function or method.
+// With type information, the package is the import path of the package declaring the function or method, and both
+// are empty if the callee cannot be resolved. Without type information, the package is the name of the receiver
+// identifier, e.g. `slog` for `slog.Info`, and both are empty for calls that are not of this form.
+func calledFunction(call *ast.CallExpr, info *types.Info) (string, string) {
+	if info != nil {
+		if fn, ok := typeutil.Callee(info, call).(*types.Func); ok && fn.Pkg() != nil {
+			return fn.Pkg().Path(), fn.Name()
+		}
+		return "", ""
+	}
+
+	selExpr, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+	x, ok := selExpr.X.(*ast.Ident)
+	if !ok {
+		return "", ""
+	}
+	return x.Name, selExpr.Sel.Name
+}
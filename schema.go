@@ -0,0 +1,107 @@
+package qawaylinter
+
+import (
+	"reflect"
+	"strconv"
+)
+
+//go:generate go run ./internal/schemagen -o qaway.schema.json
+
+// jsonSchemaProvider is implemented by types that are decoded by a custom json.Unmarshaler
+// and therefore describe their JSON schema themselves.
+type jsonSchemaProvider interface {
+	JSONSchema() map[string]any
+}
+
+// JSONSchema returns a JSON schema (draft 7) of the settings including all registered rules.
+// The schema describes the content of a standalone configuration file and of the `settings` block in golangci-lint.
+func JSONSchema() map[string]any {
+	schema := schemaForType(reflect.TypeFor[Settings]())
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "qawaylinter settings"
+	return schema
+}
+
+// JSONSchema describes a target with its fields and all registered rules.
+// Rules registered with a custom decoder accept any value.
+func (t Rules) JSONSchema() map[string]any {
+	properties := make(map[string]any)
+	for name, field := range jsonFields(reflect.TypeFor[Rules]()) {
+		if field.Type != reflect.TypeFor[*Rules]() {
+			properties[name] = schemaForType(field.Type)
+		}
+	}
+
+	tests := checksSchema()
+	properties["tests"] = tests
+	for key, schema := range tests["properties"].(map[string]any) {
+		properties[key] = schema
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// checksSchema describes an object containing the configuration of all registered rules.
+func checksSchema() map[string]any {
+	properties := make(map[string]any)
+	for _, key := range RegisteredRules() {
+		rule, _ := lookupRule(key)
+		if rule.configType == nil {
+			properties[key] = map[string]any{}
+			continue
+		}
+		properties[key] = schemaForType(rule.configType)
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// schemaForType derives the JSON schema of a Go type in the same way as DecodeStrict decodes it.
+func schemaForType(t reflect.Type) map[string]any {
+	if provider, ok := reflect.New(t).Elem().Interface().(jsonSchemaProvider); ok {
+		return provider.JSONSchema()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaForType(t.Elem())
+	case reflect.Struct:
+		properties := make(map[string]any)
+		for name, field := range jsonFields(t) {
+			property := schemaForType(field.Type)
+			for _, keyword := range []string{"minimum", "maximum"} {
+				if value, ok := field.Tag.Lookup(keyword); ok {
+					property[keyword], _ = strconv.ParseFloat(value, 64)
+				}
+			}
+			properties[name] = property
+		}
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{
+			"type":  "array",
+			"items": schemaForType(t.Elem()),
+		}
+	case reflect.Map:
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": schemaForType(t.Elem()),
+		}
+	case reflect.Interface:
+		return map[string]any{}
+	default:
+		return map[string]any{"type": jsonTypeName(t)}
+	}
+}
@@ -0,0 +1,22 @@
+package qawaylinter
+
+import (
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	schema := JSONSchema()
+
+	rules := schema["properties"].(map[string]any)["rules"].(map[string]any)
+	target := rules["items"].(map[string]any)
+	if target["additionalProperties"] != false {
+		t.Errorf("Expected unknown rules to be rejected, but got %v", target["additionalProperties"])
+	}
+
+	functions := target["properties"].(map[string]any)["functions"].(map[string]any)
+	params := functions["properties"].(map[string]any)["params"].(map[string]any)
+	density := params["properties"].(map[string]any)["minCommentDensity"].(map[string]any)
+	if density["type"] != "number" || density["minimum"] != 0.0 || density["maximum"] != 1.0 {
+		t.Errorf("Expected minCommentDensity to be a number between 0 and 1, but got %v", density)
+	}
+}
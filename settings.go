@@ -1,8 +1,13 @@
 package qawaylinter
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"go/types"
-	"strings"
+	"reflect"
+	"sort"
+	"strconv"
 )
 
 // Settings is the root configuration object for the linter.
@@ -14,60 +19,379 @@ type Settings struct {
 // Filters allow users to customize to which nodes a rule should apply to.
 // For example, interfaces in the domain package may require comments, but interfaces in an internal dev package may not.
 //
-// The object is divided into individual rule attributes instead of one generic `rules` object.
-// This makes JSON deserialization of the configuration easier.
-// In addition, it works around limitations in Generics support in Go.
+// Apart from the fields of the struct, every key of the object refers to a rule kind in the registry (see RegisterRule).
+// The configuration below the key is decoded by the decoder registered for that key.
 type Rules struct {
 	Packages []string `json:"packages"`
+	// ExcludePackages contains patterns of packages the target does not apply to, even if they match `packages`.
+	ExcludePackages []string `json:"excludePackages"`
+	// IncludeFiles contains glob patterns of files the target applies to. If empty, the target applies to all files.
+	IncludeFiles []string `json:"includeFiles"`
+	// ExcludeFiles contains glob patterns of files the target does not apply to.
+	ExcludeFiles []string `json:"excludeFiles"`
+	// Inherit determines if parameters and filters of less specific targets are inherited. Defaults to true.
+	Inherit *bool `json:"inherit"`
+	// Tests contains the rules that apply to test files. Test files are not linted if no rules are set.
+	Tests *Rules `json:"tests"`
 
-	FunctionRule  *FunctionRule[FunctionRuleResults]   `json:"functions"`
-	InterfaceRule *InterfaceRule[InterfaceRuleResults] `json:"interfaces"`
-	StructRule    *StructRule[StructRuleResults]       `json:"structs"`
+	// Checks contains the configured rules. Key: rule key as registered, value: the decoded rule.
+	Checks map[string]Checker `json:"-"`
+
+	// rawChecks contains the configuration of the rules as it was decoded. It is used to merge targets field by field.
+	rawChecks map[string]json.RawMessage
+	// packagePatterns and excludePatterns contain the compiled Packages and ExcludePackages, see compilePatterns.
+	packagePatterns []packagePattern
+	excludePatterns []packagePattern
+}
+
+// UnmarshalJSON decodes the packages of the target and dispatches all other keys to the registered rule decoders.
+// Keys without a registered rule are rejected.
+func (t *Rules) UnmarshalJSON(data []byte) error {
+	return t.decode(data, true)
+}
+
+// decode decodes a target. If isTarget is false, only rules are accepted, which is used for the `tests` block.
+func (t *Rules) decode(data []byte, isTarget bool) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return &ConfigError{Err: errors.New("expected object")}
+	}
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	t.Checks = make(map[string]Checker)
+	t.rawChecks = make(map[string]json.RawMessage)
+	for _, key := range keys {
+		var err error
+		switch {
+		case !isTarget:
+			err = t.decodeCheck(key, raw[key])
+		case key == "packages":
+			err = decodePackagePatterns(raw[key], &t.Packages, &t.packagePatterns)
+		case key == "excludePackages":
+			err = decodePackagePatterns(raw[key], &t.ExcludePackages, &t.excludePatterns)
+		case key == "includeFiles":
+			err = decodeFilePatterns(raw[key], &t.IncludeFiles)
+		case key == "excludeFiles":
+			err = decodeFilePatterns(raw[key], &t.ExcludeFiles)
+		case key == "inherit":
+			err = decodeJSON(raw[key], reflect.ValueOf(&t.Inherit).Elem())
+		case key == "tests":
+			t.Tests = &Rules{}
+			err = t.Tests.decode(raw[key], false)
+		default:
+			err = t.decodeCheck(key, raw[key])
+		}
+		if err != nil {
+			return prefixConfigError(key, err)
+		}
+	}
+	return nil
+}
+
+// decodeCheck decodes the configuration of a rule using the decoder registered for the key.
+func (t *Rules) decodeCheck(key string, data json.RawMessage) error {
+	rule, ok := lookupRule(key)
+	if !ok {
+		return errors.New("unknown rule")
+	}
+	checker, err := rule.decoder(data)
+	if err != nil {
+		return err
+	}
+	t.Checks[key] = checker
+	t.rawChecks[key] = data
+	return nil
+}
+
+// decodePackagePatterns decodes a list of package patterns and compiles them, so that they are compiled only once.
+func decodePackagePatterns(data json.RawMessage, patterns *[]string, compiled *[]packagePattern) error {
+	if err := decodeJSON(data, reflect.ValueOf(patterns).Elem()); err != nil {
+		return err
+	}
+	var err error
+	*compiled, err = compilePackagePatterns(*patterns)
+	return err
+}
+
+// compilePackagePatterns compiles a list of package patterns. Invalid patterns are rejected with a ConfigError.
+func compilePackagePatterns(patterns []string) ([]packagePattern, error) {
+	compiled := make([]packagePattern, 0, len(patterns))
+	for i, pattern := range patterns {
+		p, err := compilePackagePattern(pattern)
+		if err != nil {
+			return nil, &ConfigError{Path: "[" + strconv.Itoa(i) + "]", Err: err}
+		}
+		compiled = append(compiled, p)
+	}
+	return compiled, nil
+}
+
+// compilePatterns compiles the package patterns of all targets that were not decoded from a configuration,
+// e.g. targets created in code. It is called once before the analysis.
+func (s *Settings) compilePatterns() error {
+	for i := range s.Targets {
+		if err := s.Targets[i].compilePatterns(); err != nil {
+			return prefixConfigError("rules["+strconv.Itoa(i)+"]", err)
+		}
+	}
+	return nil
+}
+
+// compilePatterns compiles Packages and ExcludePackages unless they have already been compiled while decoding.
+func (t *Rules) compilePatterns() error {
+	if t.compiled() {
+		return nil
+	}
+	var err error
+	if t.packagePatterns, err = compilePackagePatterns(t.Packages); err != nil {
+		return prefixConfigError("packages", err)
+	}
+	if t.excludePatterns, err = compilePackagePatterns(t.ExcludePackages); err != nil {
+		return prefixConfigError("excludePackages", err)
+	}
+	return nil
+}
+
+// compiled determines if the compiled package patterns correspond to Packages and ExcludePackages.
+func (t Rules) compiled() bool {
+	return len(t.packagePatterns) == len(t.Packages) && len(t.excludePatterns) == len(t.ExcludePackages)
+}
+
+// OrderedChecks returns the configured rules sorted by their key, so that rules are always executed in the same order.
+func (t Rules) OrderedChecks() []Checker {
+	keys := make([]string, 0, len(t.Checks))
+	for key := range t.Checks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	checks := make([]Checker, 0, len(keys))
+	for _, key := range keys {
+		checks = append(checks, t.Checks[key])
+	}
+	return checks
 }
 
 // MatchesPackage checks if the given package matches the target.
-// Returns true if the full package path starts with any of the target packages.
-// Also returns the package that matched.
-// For example, if the target is `["example.com/foo"]`, the package `example.com/foo/bar` will match.
+// Returns true if any pattern in `packages` and no pattern in `excludePackages` matches the package path.
+// Also returns the most specific pattern that matched.
+// For example, if the target is `["example.com/foo"]`, the package `example.com/foo/bar` will match,
+// but `example.com/foobar` will not. See packagePattern for the supported patterns.
+// Patterns are compiled when the settings are decoded or the analyzers are built. Targets that were not prepared this
+// way compile their patterns on each call and do not match if a pattern is invalid.
 func (t Rules) MatchesPackage(pkg *types.Package) (bool, string) {
-	for _, p := range t.Packages {
-		if strings.HasPrefix(pkg.Path(), p) {
-			return true, p
-		}
+	if _, index := t.matchingPattern(pkg); index >= 0 {
+		return true, t.Packages[index]
 	}
 	return false, ""
 }
 
-// GetMatchingTarget finds the most specific target that matches the given package.
-func (s Settings) GetMatchingTarget(pkg *types.Package) *Rules {
-	var matchingTargets = make(map[*Rules]string)
+// matchingPattern returns the most specific pattern of `packages` that matches the package and its index.
+// The index is -1 if no pattern matches or a pattern of `excludePackages` matches.
+func (t Rules) matchingPattern(pkg *types.Package) (packagePattern, int) {
+	if !t.compiled() && t.compilePatterns() != nil {
+		return packagePattern{}, -1
+	}
+	for _, pattern := range t.excludePatterns {
+		if pattern.matches(pkg.Path()) {
+			return packagePattern{}, -1
+		}
+	}
 
-	for _, t := range s.Targets {
-		if matches, p := t.MatchesPackage(pkg); matches {
-			matchingTargets[&t] = p
+	mostSpecific := -1
+	for i, pattern := range t.packagePatterns {
+		if pattern.matches(pkg.Path()) && (mostSpecific < 0 || pattern.moreSpecificThan(t.packagePatterns[mostSpecific])) {
+			mostSpecific = i
 		}
 	}
+	if mostSpecific < 0 {
+		return packagePattern{}, -1
+	}
+	return t.packagePatterns[mostSpecific], mostSpecific
+}
 
-	return findMostConcreteTarget(matchingTargets)
+// targetMatch is a target together with the package pattern that matched.
+type targetMatch struct {
+	target  *Rules
+	pattern packagePattern
+	// path of the target in the configuration, e.g. `rules[2]`. It is used to report invalid merged configurations.
+	path string
 }
 
-// findMostConcreteTarget finds the most specific target from a list of matching targets.
-// A target is more specific if the package that matches the given node are more specific than the
-// matching package from the other node.
-// A subpackage is more specific than a package.
-func findMostConcreteTarget(matchingTargets map[*Rules]string) *Rules {
-	var mostConcreteTarget *Rules
+// GetMatchingTarget determines the rules that apply to the given package.
+// All targets matching the package are merged, where more specific targets override the parameters and filters
+// they set explicitly and inherit all others from less specific targets.
+// Targets that set `inherit: false` do not inherit anything, i.e. only the target itself and more specific ones are merged.
+// An error is returned if a merged rule configuration is invalid, e.g. if one target sets `exportedOnly` and a more
+// specific one sets `unexportedOnly`.
+func (s Settings) GetMatchingTarget(pkg *types.Package) (*Rules, error) {
+	var matches []targetMatch
+
+	for i := range s.Targets {
+		if pattern, index := s.Targets[i].matchingPattern(pkg); index >= 0 {
+			matches = append(matches, targetMatch{target: &s.Targets[i], pattern: pattern, path: "rules[" + strconv.Itoa(i) + "]"})
+		}
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	sortBySpecificity(matches)
+	target, err := mergeTargets(matches)
+	if err != nil {
+		return nil, fmt.Errorf("merging the rules for package %s: %w", pkg.Path(), err)
+	}
+	return target, nil
+}
+
+// RequiresTypesInfo determines if any configured rule requires type information, see TypesInfoRequirer.
+// Targets are checked separately, so a rule requires type information if any target configures it accordingly.
+func (s Settings) RequiresTypesInfo() bool {
+	for _, target := range s.Targets {
+		if target.requiresTypesInfo() {
+			return true
+		}
+	}
+	return false
+}
 
-	for target, pkg := range matchingTargets {
-		if mostConcreteTarget == nil {
-			mostConcreteTarget = target
-			continue
+// requiresTypesInfo determines if a rule of the target or of its test rules requires type information.
+func (t Rules) requiresTypesInfo() bool {
+	for _, check := range t.Checks {
+		if requirer, ok := check.(TypesInfoRequirer); ok && requirer.RequiresTypesInfo() {
+			return true
 		}
+	}
+	return t.Tests != nil && t.Tests.requiresTypesInfo()
+}
 
-		if len(pkg) > len(matchingTargets[mostConcreteTarget]) {
-			mostConcreteTarget = target
+// sortBySpecificity sorts the matching targets from least to most specific.
+// A target is more specific if the pattern that matches the package is more specific than the
+// matching pattern of the other target (see packagePattern.specificity).
+// A subpackage is more specific than a package. Targets that are equally specific keep their order in the configuration.
+func sortBySpecificity(matches []targetMatch) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[j].pattern.moreSpecificThan(matches[i].pattern)
+	})
+}
+
+// mergeTargets merges targets ordered from least to most specific into a single target.
+// File patterns and test rules that are not set by a target are inherited from less specific targets.
+// An error is returned if the merged configuration of a rule cannot be decoded.
+func mergeTargets(matches []targetMatch) (*Rules, error) {
+	first := len(matches) - 1
+	for first > 0 && matches[first].target.inherits() {
+		first--
+	}
+	matches = matches[first:]
+
+	mostConcrete := matches[len(matches)-1].target
+	if len(matches) == 1 {
+		return mostConcrete, nil
+	}
+
+	var tests []targetMatch
+	for _, match := range matches {
+		if match.target.Tests != nil {
+			tests = append(tests, targetMatch{target: match.target.Tests, path: match.path + ".tests"})
 		}
 	}
 
-	return mostConcreteTarget
+	merged, err := mergeChecks(matches)
+	if err != nil {
+		return nil, err
+	}
+	merged.Packages = mostConcrete.Packages
+	merged.ExcludePackages = mostConcrete.ExcludePackages
+	merged.packagePatterns = mostConcrete.packagePatterns
+	merged.excludePatterns = mostConcrete.excludePatterns
+	merged.Inherit = mostConcrete.Inherit
+	for _, match := range matches {
+		if match.target.IncludeFiles != nil {
+			merged.IncludeFiles = match.target.IncludeFiles
+		}
+		if match.target.ExcludeFiles != nil {
+			merged.ExcludeFiles = match.target.ExcludeFiles
+		}
+	}
+	if len(tests) > 0 {
+		if merged.Tests, err = mergeChecks(tests); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// mergeChecks merges the rules of the given targets, ordered from least to most specific.
+// Rules are merged on the level of their configuration, so rules are only merged if all targets were decoded from
+// a configuration. Otherwise, the rule of the most specific target is used.
+// The error of a merged configuration that cannot be decoded refers to the target that was merged last.
+func mergeChecks(matches []targetMatch) (*Rules, error) {
+	merged := &Rules{
+		Checks:    make(map[string]Checker),
+		rawChecks: make(map[string]json.RawMessage),
+	}
+	for _, match := range matches {
+		target := match.target
+		for key, checker := range target.Checks {
+			raw, hasRaw := target.rawChecks[key]
+			previous, inherited := merged.rawChecks[key]
+
+			merged.Checks[key] = checker
+			delete(merged.rawChecks, key)
+			if !hasRaw {
+				continue
+			}
+			merged.rawChecks[key] = raw
+			if !inherited {
+				continue
+			}
+
+			rule, ok := lookupRule(key)
+			if !ok {
+				continue
+			}
+			raw = mergeJSON(previous, raw)
+			checker, err := rule.decoder(raw)
+			if err != nil {
+				return nil, prefixConfigError(match.path+"."+key, err)
+			}
+			merged.Checks[key] = checker
+			merged.rawChecks[key] = raw
+		}
+	}
+	return merged, nil
+}
+
+// inherits checks if the target inherits parameters from less specific targets.
+func (t Rules) inherits() bool {
+	return t.Inherit == nil || *t.Inherit
+}
+
+// mergeJSON merges the override into the base configuration.
+// Objects are merged key by key, all other values of the override replace the value of the base.
+func mergeJSON(base json.RawMessage, override json.RawMessage) json.RawMessage {
+	var baseObject, overrideObject map[string]json.RawMessage
+	if json.Unmarshal(base, &baseObject) != nil || json.Unmarshal(override, &overrideObject) != nil ||
+		baseObject == nil || overrideObject == nil {
+		return override
+	}
+
+	for key, value := range overrideObject {
+		if previous, ok := baseObject[key]; ok {
+			value = mergeJSON(previous, value)
+		}
+		baseObject[key] = value
+	}
+
+	merged, err := json.Marshal(baseObject)
+	if err != nil {
+		return override
+	}
+	return merged
 }
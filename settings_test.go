@@ -1,7 +1,10 @@
 package qawaylinter
 
 import (
+	"errors"
 	"go/types"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -15,7 +18,10 @@ func TestGetMatchingTarget(t *testing.T) {
 	}
 
 	pkg := types.NewPackage("example.com/foo/bar/baz/qux", "qux")
-	matchingTarget := settings.GetMatchingTarget(pkg)
+	matchingTarget, err := settings.GetMatchingTarget(pkg)
+	if err != nil {
+		t.Fatalf("Failed to get matching target: %s", err)
+	}
 	expected := "example.com/foo/bar/baz"
 
 	if matchingTarget == nil || matchingTarget.Packages[0] != expected {
@@ -23,22 +29,109 @@ func TestGetMatchingTarget(t *testing.T) {
 	}
 }
 
-func TestFindMostConcreteTarget(t *testing.T) {
+func TestMergeTargets(t *testing.T) {
 	targets := []Rules{
 		{Packages: []string{"example.com/foo"}},
 		{Packages: []string{"example.com/foo/bar"}},
 		{Packages: []string{"example.com/foo/bar/baz"}},
 	}
 
-	matchingTargets := make(map[*Rules]string)
+	var matchingTargets []targetMatch
 	for i := range targets {
-		matchingTargets[&targets[i]] = targets[i].Packages[0]
+		pattern, _ := compilePackagePattern(targets[i].Packages[0])
+		matchingTargets = append(matchingTargets, targetMatch{target: &targets[i], pattern: pattern})
 	}
 
-	mostConcreteTarget := findMostConcreteTarget(matchingTargets)
+	merged, err := mergeTargets(matchingTargets)
+	if err != nil {
+		t.Fatalf("Failed to merge targets: %s", err)
+	}
 	expected := "example.com/foo/bar/baz"
+	if merged == nil || merged.Packages[0] != expected {
+		t.Errorf("Expected merged target to have packages of %s, but got %v", expected, merged)
+	}
+}
+
+func TestMergeTargetsInvalidConfiguration(t *testing.T) {
+	settings, err := DecodeSettings(map[string]any{
+		"rules": []any{
+			map[string]any{
+				"packages":  []any{"example.com/foo"},
+				"functions": map[string]any{"filters": map[string]any{"exportedOnly": true}},
+			},
+			map[string]any{
+				"packages":  []any{"example.com/foo/bar"},
+				"functions": map[string]any{"filters": map[string]any{"unexportedOnly": true}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to decode settings: %s", err)
+	}
+
+	target, err := settings.GetMatchingTarget(types.NewPackage("example.com/foo/bar", "bar"))
+	var configErr *ConfigError
+	if target != nil || !errors.As(err, &configErr) || !strings.HasPrefix(configErr.Path, "rules[1].functions.filters") {
+		t.Errorf("Expected config error for rules[1].functions.filters, but got %v", err)
+	}
+}
 
-	if mostConcreteTarget == nil || mostConcreteTarget.Packages[0] != expected {
-		t.Errorf("Expected most concrete target to be %s, but got %v", expected, mostConcreteTarget)
+func TestGetMatchingTargetInheritance(t *testing.T) {
+	settings, err := DecodeSettings(map[string]any{
+		"rules": []any{
+			map[string]any{
+				"packages": []any{"example.com/foo"},
+				"functions": map[string]any{
+					"filters": map[string]any{"minLinesOfCode": 10},
+					"params":  map[string]any{"requireHeadlineComment": true, "minCommentDensity": 0.1},
+				},
+				"structs": map[string]any{"params": map[string]any{"requireFieldComment": true}},
+			},
+			map[string]any{
+				"packages":  []any{"example.com/foo/bar"},
+				"functions": map[string]any{"params": map[string]any{"trivialCommentThreshold": 0.5, "minCommentDensity": 0}},
+			},
+			map[string]any{
+				"packages":  []any{"example.com/foo/baz"},
+				"inherit":   false,
+				"functions": map[string]any{"params": map[string]any{"trivialCommentThreshold": 0.5}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to decode settings: %s", err)
+	}
+
+	functionParams := func(target *Rules) FunctionRule[FunctionRuleResults] {
+		return target.Checks["functions"].(ruleChecker[FunctionRuleResults]).rule.(FunctionRule[FunctionRuleResults])
+	}
+
+	inherited, err := settings.GetMatchingTarget(types.NewPackage("example.com/foo/bar/qux", "qux"))
+	if err != nil {
+		t.Fatalf("Failed to get matching target: %s", err)
+	}
+	expected := FunctionRule[FunctionRuleResults]{
+		Filters: FunctionFilters{MinLinesOfCode: 10},
+		Params:  FunctionRuleParameters{RequireHeadlineComment: true, MinCommentDensity: 0, TrivialCommentThreshold: 0.5},
+	}
+	if actual := functionParams(inherited); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected merged function rule %+v, but got %+v", expected, actual)
+	}
+	if _, ok := inherited.Checks["structs"]; !ok {
+		t.Errorf("Expected structs rule to be inherited, but got %v", inherited.Checks)
+	}
+
+	notInherited, err := settings.GetMatchingTarget(types.NewPackage("example.com/foo/baz", "baz"))
+	if err != nil {
+		t.Fatalf("Failed to get matching target: %s", err)
+	}
+	expected = FunctionRule[FunctionRuleResults]{
+		Params: FunctionRuleParameters{TrivialCommentThreshold: 0.5},
+	}
+	if actual := functionParams(notInherited); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected function rule %+v, but got %+v", expected, actual)
+	}
+	if _, ok := notInherited.Checks["structs"]; ok {
+		t.Errorf("Expected structs rule not to be inherited, but got %v", notInherited.Checks)
 	}
 }
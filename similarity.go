@@ -1,11 +1,202 @@
 package qawaylinter
 
 import (
+	"fmt"
 	"github.com/adrg/strutil"
 	"github.com/adrg/strutil/metrics"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
 )
 
-// StringSimilarity returns the similarity between two strings.
-func StringSimilarity(a string, b string) float64 {
-	return strutil.Similarity(a, b, metrics.NewLevenshtein())
+// Metrics to compare a comment with the name of the documented symbol, see the `similarityMetric` parameter.
+const (
+	// JaccardMetric is the share of distinct words that occur both in the name and in the comment.
+	JaccardMetric = "jaccard"
+	// JaroWinklerMetric is the Jaro-Winkler similarity of the words of the name and the comment.
+	JaroWinklerMetric = "jaroWinkler"
+	// LevenshteinMetric is the Levenshtein similarity of the words of the name and the first sentence of the comment.
+	LevenshteinMetric = "levenshtein"
+)
+
+// similarityMetrics are the valid values of the `similarityMetric` parameter. The first one is the default.
+var similarityMetrics = []string{LevenshteinMetric, JaccardMetric, JaroWinklerMetric}
+
+// stopWords do not contribute to the meaning of a comment and are ignored for the similarity.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true, "for": true,
+	"from": true, "given": true, "if": true, "in": true, "is": true, "it": true, "its": true, "of": true, "on": true,
+	"or": true, "the": true, "this": true, "that": true, "to": true, "with": true,
+}
+
+// wordPattern matches the words of a comment, which may be identifiers such as `snake_case` or `camelCase`.
+var wordPattern = regexp.MustCompile(`[\pL\pN_]+`)
+
+// Similarity describes how similar a comment is to the name of the documented symbol.
+type Similarity struct {
+	// Score between 0 (different) and 1 (identical) according to the metric.
+	Score float64
+	// Words of the name that are restated by the comment, in the order of the name.
+	RestatedWords []string
+	// Indicates that all words of the comment are part of the name.
+	OnlyRestates bool
+}
+
+// Explanation describes which words of the name are restated by the comment.
+func (s Similarity) Explanation() string {
+	if len(s.RestatedWords) == 0 {
+		return ""
+	}
+	if s.OnlyRestates {
+		return "comment only restates words: " + strings.Join(s.RestatedWords, ", ")
+	}
+	return "comment restates words: " + strings.Join(s.RestatedWords, ", ")
+}
+
+// explanationSuffix appends the explanation of a similarity to a diagnostic, e.g. `, comment only restates words: value`.
+func explanationSuffix(similarity Similarity) string {
+	if explanation := similarity.Explanation(); explanation != "" {
+		return ", " + explanation
+	}
+	return ""
+}
+
+// validateSimilarityMetric ensures that the metric is one of the supported metrics. An empty metric selects the default.
+func validateSimilarityMetric(metric string) error {
+	if metric != "" && !slices.Contains(similarityMetrics, metric) {
+		return fmt.Errorf("unknown metric %q, expected one of %s", metric, strings.Join(similarityMetrics, ", "))
+	}
+	return nil
+}
+
+// CommentSimilarity compares a comment with the name of the documented symbol using the given metric.
+// Both are split into words, e.g. `download` and `artifacts` for `downloadArtifacts`, stop words are removed and the
+// words are reduced to their stem. The name at the beginning of the comment, as required by the Go doc conventions,
+// is not taken into account.
+func CommentSimilarity(name string, comment string, metric string) Similarity {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return Similarity{}
+	}
+	if first, rest, _ := strings.Cut(comment, " "); strings.EqualFold(strings.TrimRight(first, ".,:;"), name) {
+		comment = rest
+	}
+
+	nameWords := identifierWords(name)
+	nameStems := make([]string, len(nameWords))
+	for i, word := range nameWords {
+		nameStems[i] = stem(word)
+	}
+	commentStems := normalizedWords(comment)
+
+	similarity := Similarity{OnlyRestates: true}
+	for _, word := range commentStems {
+		if !slices.Contains(nameStems, word) {
+			similarity.OnlyRestates = false
+		}
+	}
+	for i, word := range nameWords {
+		if slices.Contains(commentStems, nameStems[i]) && !slices.Contains(similarity.RestatedWords, word) {
+			similarity.RestatedWords = append(similarity.RestatedWords, word)
+		}
+	}
+
+	switch metric {
+	case JaccardMetric:
+		similarity.Score = jaccard(nameStems, commentStems)
+	case JaroWinklerMetric:
+		similarity.Score = wordSimilarity(nameStems, commentStems, metrics.NewJaroWinkler())
+	default:
+		similarity.Score = wordSimilarity(nameStems, normalizedWords(firstSentence(comment)), metrics.NewLevenshtein())
+	}
+	return similarity
+}
+
+// wordSimilarity compares two lists of words with a string metric. A comment without words except for the name of
+// the symbol is identical to the name.
+func wordSimilarity(nameWords []string, commentWords []string, metric strutil.StringMetric) float64 {
+	if len(commentWords) == 0 {
+		return 1
+	}
+	return strutil.Similarity(strings.Join(nameWords, " "), strings.Join(commentWords, " "), metric)
+}
+
+// jaccard returns the number of distinct words in both lists divided by the number of distinct words in any list.
+func jaccard(a []string, b []string) float64 {
+	if len(b) == 0 {
+		return 1
+	}
+	union := make(map[string]bool)
+	for _, word := range a {
+		union[word] = true
+	}
+	intersection := 0
+	for _, word := range slices.Compact(slices.Sorted(slices.Values(b))) {
+		if union[word] {
+			intersection++
+		}
+		union[word] = true
+	}
+	return float64(intersection) / float64(len(union))
+}
+
+// normalizedWords splits a text into lowercase words without stop words and reduces them to their stem.
+func normalizedWords(text string) []string {
+	var words []string
+	for _, token := range wordPattern.FindAllString(text, -1) {
+		for _, word := range identifierWords(token) {
+			if !stopWords[word] {
+				words = append(words, stem(word))
+			}
+		}
+	}
+	return words
+}
+
+// identifierWords splits an identifier into lowercase words at underscores and changes of case,
+// e.g. `HTTPServer` into `http` and `server` or `max_retries` into `max` and `retries`.
+func identifierWords(identifier string) []string {
+	var words []string
+	for _, part := range strings.FieldsFunc(identifier, func(r rune) bool { return r == '_' }) {
+		runes := []rune(part)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			lowerToUpper := unicode.IsLower(runes[i-1]) && unicode.IsUpper(runes[i])
+			acronymEnd := i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i+1])
+			if lowerToUpper || acronymEnd {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+				start = i
+			}
+		}
+		words = append(words, strings.ToLower(string(runes[start:])))
+	}
+	return words
+}
+
+// stem reduces a word to its stem by removing common English suffixes, e.g. `downloads` and `downloading` to
+// `download`. Short words are not changed.
+func stem(word string) string {
+	for _, suffix := range []string{"ing", "ies", "ed", "s"} {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && len(stem) >= 3 {
+			if suffix == "ies" {
+				return stem + "y"
+			}
+			return stem
+		}
+	}
+	return word
+}
+
+// sentenceEndPattern matches the end of a sentence, which is followed by the capitalized start of the next sentence.
+// Abbreviations such as `e.g. foo` do not end a sentence.
+var sentenceEndPattern = regexp.MustCompile(`[.!?]\s+\p{Lu}`)
+
+// firstSentence returns the text up to the end of the first sentence or paragraph.
+func firstSentence(text string) string {
+	text, _, _ = strings.Cut(text, "\n\n")
+	if end := sentenceEndPattern.FindStringIndex(text); end != nil {
+		return text[:end[0]+1]
+	}
+	return text
 }
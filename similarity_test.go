@@ -1,42 +1,89 @@
 package qawaylinter
 
 import (
+	"slices"
 	"testing"
 )
 
-func TestStringSimilarity(t *testing.T) {
+func TestIdentifierWords(t *testing.T) {
 	tests := []struct {
-		name     string
-		a        string
-		b        string
-		expected float64
+		identifier string
+		expected   []string
+	}{
+		{identifier: "downloadArtifacts", expected: []string{"download", "artifacts"}},
+		{identifier: "HTTPServer", expected: []string{"http", "server"}},
+		{identifier: "max_retries", expected: []string{"max", "retries"}},
+		{identifier: "ID", expected: []string{"id"}},
+		{identifier: "parseURLQuery", expected: []string{"parse", "url", "query"}},
+	}
+
+	for _, tt := range tests {
+		if actual := identifierWords(tt.identifier); !slices.Equal(actual, tt.expected) {
+			t.Errorf("identifierWords(%q) = %v; want %v", tt.identifier, actual, tt.expected)
+		}
+	}
+}
+
+func TestCommentSimilarity(t *testing.T) {
+	tests := []struct {
+		name        string
+		comment     string
+		metric      string
+		expected    float64
+		explanation string
 	}{
 		{
-			name:     "Identical strings",
-			a:        "downloadArtifacts",
-			b:        "downloadArtifacts downloads the artifacts",
-			expected: 0.41463414634146345,
+			name:        "downloadArtifacts",
+			comment:     "downloadArtifacts downloads the artifacts",
+			metric:      LevenshteinMetric,
+			expected:    1,
+			explanation: "comment only restates words: download, artifacts",
 		},
 		{
-			name:     "Similar strings",
-			a:        "getValue",
-			b:        "getValue returns the value",
-			expected: 0.3076923076923077,
+			name:        "getValue",
+			comment:     "getValue gets the value.",
+			metric:      JaccardMetric,
+			expected:    1,
+			explanation: "comment only restates words: get, value",
 		},
 		{
-			name:     "Different strings",
-			a:        "Calculate",
-			b:        "Calculate uses a special algorithm to determine the business metric by taking into account the current state of the system",
-			expected: 0.07377049180327866,
+			name:        "getValue",
+			comment:     "getValue returns the cached value or loads it from the database.",
+			metric:      JaccardMetric,
+			expected:    0.16666666666666666,
+			explanation: "comment restates words: value",
+		},
+		{
+			name:        "Calculate",
+			comment:     "Calculate uses a special algorithm to determine the business metric. It is slow.",
+			metric:      LevenshteinMetric,
+			expected:    0.13043478260869568,
+			explanation: "",
+		},
+		{
+			name:        "ParseConfig",
+			comment:     "ParseConfig parses the config file.",
+			metric:      JaroWinklerMetric,
+			expected:    0.9411764705882353,
+			explanation: "comment restates words: parse, config",
 		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			similarity := StringSimilarity(tt.a, tt.b)
-			if similarity != tt.expected {
-				t.Errorf("StringSimilarity(%q, %q) = %v; want %v", tt.a, tt.b, similarity, tt.expected)
+		t.Run(tt.name+"/"+tt.metric, func(t *testing.T) {
+			similarity := CommentSimilarity(tt.name, tt.comment, tt.metric)
+			if similarity.Score != tt.expected {
+				t.Errorf("CommentSimilarity(%q, %q).Score = %v; want %v", tt.name, tt.comment, similarity.Score, tt.expected)
+			}
+			if explanation := similarity.Explanation(); explanation != tt.explanation {
+				t.Errorf("CommentSimilarity(%q, %q).Explanation() = %q; want %q", tt.name, tt.comment, explanation, tt.explanation)
 			}
 		})
 	}
 }
+
+func TestCommentSimilarityWithoutComment(t *testing.T) {
+	if similarity := CommentSimilarity("getValue", "", JaccardMetric); similarity.Score != 0 {
+		t.Errorf("Expected no similarity without comment, but got %v", similarity)
+	}
+}
@@ -2,69 +2,119 @@ package qawaylinter
 
 import (
 	"go/ast"
+	"go/token"
 	"golang.org/x/tools/go/analysis"
 )
 
+func init() {
+	RegisterJSONRule[StructRuleResults, StructRule[StructRuleResults]]("structs")
+}
+
+type StructFilters struct {
+	NameFilters
+	// MinFields determines the minimum number of fields that a struct must have to be considered.
+	// Each name of a field and each embedded field is counted.
+	MinFields int `json:"minFields" minimum:"0"`
+}
+
 type StructRuleParameters struct {
 	// RequireHeadlineComment determines if a comment must be placed on top of the interface.
 	RequireHeadlineComment bool `json:"requireHeadlineComment"`
 	// RequireFieldComment determines if a comment must be placed on top of each field in the struct.
 	RequireFieldComment bool `json:"requireFieldComment"`
+	// RequireEmbeddedFieldComment determines if a comment must be placed on top of each embedded field in the struct.
+	RequireEmbeddedFieldComment bool `json:"requireEmbeddedFieldComment"`
+	// AllowTrailingComment determines if a comment at the end of the line of a field, e.g. `Port int // listen port`,
+	// satisfies RequireFieldComment and RequireEmbeddedFieldComment.
+	AllowTrailingComment bool `json:"allowTrailingComment"`
+	// TrivialCommentThreshold determines the similarity between the struct name and its headline comment above which
+	// the comment is reported as trivial.
+	TrivialCommentThreshold float64 `json:"trivialCommentThreshold" minimum:"0" maximum:"1"`
+	// TrivialFieldCommentThreshold determines the similarity between a field name and its comment above which the
+	// comment is reported as trivial. The comment at the end of the line is used if the field has no comment on top.
+	// Comments of fields declaring multiple names are not checked.
+	TrivialFieldCommentThreshold float64 `json:"trivialFieldCommentThreshold" minimum:"0" maximum:"1"`
+	// SimilarityMetric determines how the similarity for TrivialCommentThreshold and TrivialFieldCommentThreshold is
+	// measured: `levenshtein` (default), `jaccard` or `jaroWinkler`.
+	SimilarityMetric string `json:"similarityMetric"`
+	// RequireTypeParamMention determines if the headline comment of a generic struct must mention each of its type
+	// parameters, e.g. `T` for `type Set[T comparable] struct`.
+	RequireTypeParamMention bool `json:"requireTypeParamMention"`
+	// RequireGodocConvention determines if the headline comment and the field comments must follow the Go doc comment
+	// conventions: they must start with the name of the struct (optionally preceded by `A`, `An` or `The`) or field,
+	// their first paragraph must be a full sentence and they must not be a bare TODO.
+	// Comments of fields declaring multiple names are not checked.
+	RequireGodocConvention bool `json:"requireGodocConvention"`
+	// IgnoreCommentPrefixes are prefixes of comment lines that are not counted as documentation, e.g. `TODO`.
+	// Directives, blank lines and license headers are never counted.
+	IgnoreCommentPrefixes []string `json:"ignoreCommentPrefixes"`
+	// CommentTemplate is the text/template of the doc comment that is suggested as fix for missing comments.
+	// The template receives CommentTemplateData. Defaults to `{{.Name}} ...`.
+	CommentTemplate string `json:"commentTemplate"`
+}
+
+// Validate ensures that the comment template can be parsed and that the similarity metric is known.
+func (p StructRuleParameters) Validate() error {
+	if _, err := parseCommentTemplate(p.CommentTemplate); err != nil {
+		return &ConfigError{Path: "commentTemplate", Err: err}
+	}
+	if err := validateSimilarityMetric(p.SimilarityMetric); err != nil {
+		return &ConfigError{Path: "similarityMetric", Err: err}
+	}
+	return nil
 }
 
 type StructRuleResults struct {
 	// Number of lines of comments in the headline of the interface.
 	HeadlineComments int
-	// Number of lines of comments on top of each field. Key: field name, value: number of comment lines
-	FieldComments map[string]int
+	// Position of the declaration, which is where a missing headline comment is inserted.
+	HeadlinePos token.Pos
+	// Headline comment of the struct.
+	HeadlineDoc *ast.CommentGroup
+	// Indicates the similarity between the struct name and the headline comments.
+	CommentSimilarity Similarity
+	// Comments on top of each field, ordered by their position in the source.
+	FieldComments []MemberComments
+	// Type parameters of the struct that are not mentioned in the headline comment.
+	UnmentionedTypeParams []string
 }
 
 type StructRule[ResultType StructRuleResults] struct {
-	Params StructRuleParameters `json:"params"`
+	Filters StructFilters        `json:"filters"`
+	Params  StructRuleParameters `json:"params"`
 }
 
 func (i StructRule[ResultType]) IsApplicable(node ast.Node, pass *analysis.Pass, _ *ast.File) bool {
-	n, ok := node.(*ast.GenDecl)
-	if !ok {
-		return false
-	}
-	spec, ok := n.Specs[0].(*ast.TypeSpec)
+	spec, ok := node.(*ast.TypeSpec)
 	if !ok || spec.Type == nil {
 		return false
 	}
-	if _, ok := spec.Type.(*ast.StructType); !ok {
+	stru, ok := spec.Type.(*ast.StructType)
+	if !ok {
 		return false
 	}
 
-	return true
+	return i.Filters.matchesName(spec.Name.Name) && stru.Fields.NumFields() >= i.Filters.MinFields
 }
 
-func (i StructRule[ResultType]) Analyse(node ast.Node, pass *analysis.Pass, _ *ast.File) *StructRuleResults {
-	typespec, ok := node.(*ast.GenDecl)
+func (i StructRule[ResultType]) Analyse(node ast.Node, pass *analysis.Pass, file *ast.File) *StructRuleResults {
+	typespec, ok := node.(*ast.TypeSpec)
 	if !ok {
 		return nil
 	}
 
-	typeComments := countHeadlineComments(typespec.Doc, pass.Fset)
+	doc, headlinePos := typeSpecDoc(typespec, file)
+	typeComments := countCommentLines(doc, i.Params.IgnoreCommentPrefixes)
 
-	var fieldComments = make(map[string]int)
-	ast.Inspect(node, func(n ast.Node) bool {
-		if stru, ok := n.(*ast.StructType); ok {
-			for _, field := range stru.Fields.List {
-				if _, ok := field.Type.(*ast.Ident); ok {
-					if len(field.Names) == 0 {
-						continue
-					}
-					fieldComments[field.Names[0].Name] = countHeadlineComments(field.Doc, pass.Fset)
-				}
-			}
-		}
-		return true
-	})
+	fieldComments := i.structFields(typespec.Type, nil)
 
 	return &StructRuleResults{
-		HeadlineComments: typeComments,
-		FieldComments:    fieldComments,
+		HeadlineComments:      typeComments,
+		HeadlinePos:           headlinePos,
+		HeadlineDoc:           doc,
+		CommentSimilarity:     CommentSimilarity(typespec.Name.Name, doc.Text(), i.Params.SimilarityMetric),
+		FieldComments:         fieldComments,
+		UnmentionedTypeParams: unmentionedNames(doc.Text(), typeParamNames(typespec.TypeParams)),
 	}
 }
 
@@ -72,12 +122,96 @@ func (i StructRule[ResultType]) Apply(analysis *StructRuleResults, node ast.Node
 	if analysis == nil {
 		return
 	}
+	name := node.(*ast.TypeSpec).Name.Name
 	if analysis.HeadlineComments == 0 && i.Params.RequireHeadlineComment {
-		pass.Reportf(node.Pos(), "Struct '%s' is missing required headline comment", node.(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Name.Name)
+		fixes := suggestDocComment(pass, analysis.HeadlinePos, i.Params.CommentTemplate, CommentTemplateData{Name: name, Kind: "struct"})
+		reportWithFixesf(pass, node.Pos(), "structs/requireHeadlineComment", fixes, "Struct '%s' is missing required headline comment", name)
+	}
+	if analysis.HeadlineComments > 0 && i.Params.RequireTypeParamMention {
+		for _, typeParam := range analysis.UnmentionedTypeParams {
+			reportf(pass, node.Pos(), "structs/requireTypeParamMention", "Struct '%s' does not mention type parameter '%s' in its headline comment", name, typeParam)
+		}
+	}
+	if analysis.HeadlineComments > 0 && i.Params.RequireGodocConvention {
+		reportGodocIssues(pass, node.Pos(), "structs/requireGodocConvention", analysis.HeadlineDoc, name, true)
+	}
+	if i.Params.TrivialCommentThreshold > 0 && analysis.HeadlineComments > 0 && analysis.CommentSimilarity.Score > i.Params.TrivialCommentThreshold {
+		reportf(pass, node.Pos(), "structs/trivialCommentThreshold", "Struct '%s' has a trivial comment. Similarity to struct name: %.0f%%%s", name, analysis.CommentSimilarity.Score*100, explanationSuffix(analysis.CommentSimilarity))
 	}
-	for name, comments := range analysis.FieldComments {
-		if comments == 0 && i.Params.RequireFieldComment {
-			pass.Reportf(node.Pos(), "Field '%s' is missing required comment", name)
+	for _, field := range analysis.FieldComments {
+		if field.Doc != nil && i.Params.RequireGodocConvention {
+			reportGodocIssues(pass, field.Pos, "structs/requireGodocConvention", field.Doc, field.Name, false)
 		}
+		if i.Params.TrivialFieldCommentThreshold > 0 && field.CommentSimilarity.Score > i.Params.TrivialFieldCommentThreshold {
+			reportf(pass, field.Pos, "structs/trivialFieldCommentThreshold", "Field '%s' has a trivial comment. Similarity to field name: %.0f%%%s", field.Name, field.CommentSimilarity.Score*100, explanationSuffix(field.CommentSimilarity))
+		}
+		if field.Comments > 0 || (i.Params.AllowTrailingComment && field.TrailingComments > 0) {
+			continue
+		}
+		if field.Embedded && i.Params.RequireEmbeddedFieldComment {
+			fixes := suggestDocComment(pass, field.Pos, i.Params.CommentTemplate, CommentTemplateData{Name: field.Name, Kind: "embedded field"})
+			reportWithFixesf(pass, field.Pos, "structs/requireEmbeddedFieldComment", fixes, "Embedded field '%s' is missing required comment", field.Name)
+		}
+		if !field.Embedded && i.Params.RequireFieldComment {
+			fixes := suggestDocComment(pass, field.Pos, i.Params.CommentTemplate, CommentTemplateData{Name: field.Name, Kind: "field"})
+			reportWithFixesf(pass, field.Pos, "structs/requireFieldComment", fixes, "Field '%s' is missing required comment", field.Name)
+		}
+	}
+}
+
+// structFields appends the fields of the structs in the given type expression to members in source order.
+// Fields of nested anonymous structs directly follow the field they are declared in.
+func (i StructRule[ResultType]) structFields(expr ast.Expr, members []MemberComments) []MemberComments {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		stru, ok := n.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		for _, field := range stru.Fields.List {
+			members = append(members, fieldMembers(field, i.Params.IgnoreCommentPrefixes, i.Params.SimilarityMetric)...)
+			members = i.structFields(field.Type, members)
+		}
+		return false
+	})
+	return members
+}
+
+// fieldMembers returns the comments of a field for each of its names.
+// A field such as `A, B int` results in two members sharing the same comments.
+// For embedded fields, the name of the embedded type is used, e.g. `Config` for `*config.Config`.
+// The similarity of the comment to the name is measured with the given metric for fields with a single name.
+func fieldMembers(field *ast.Field, ignorePrefixes []string, metric string) []MemberComments {
+	comments := countCommentLines(field.Doc, ignorePrefixes)
+	trailingComments := countCommentLines(field.Comment, ignorePrefixes)
+	commentText := field.Doc.Text()
+	if comments == 0 {
+		commentText = field.Comment.Text()
+	}
+
+	if len(field.Names) == 0 {
+		return []MemberComments{{
+			Name:              typeName(field.Type),
+			Pos:               field.Type.Pos(),
+			Comments:          comments,
+			TrailingComments:  trailingComments,
+			Embedded:          true,
+			Doc:               field.Doc,
+			CommentSimilarity: CommentSimilarity(typeName(field.Type), commentText, metric),
+		}}
+	}
+
+	members := make([]MemberComments, 0, len(field.Names))
+	for _, name := range field.Names {
+		members = append(members, MemberComments{
+			Name:             name.Name,
+			Pos:              name.Pos(),
+			Comments:         comments,
+			TrailingComments: trailingComments,
+		})
+	}
+	if len(members) == 1 {
+		members[0].Doc = field.Doc
+		members[0].CommentSimilarity = CommentSimilarity(members[0].Name, commentText, metric)
 	}
+	return members
 }
@@ -1,9 +1,14 @@
 package qawaylinter
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -18,11 +23,13 @@ func TestStructRule(t *testing.T) {
 		Targets: []Rules{
 			{
 				Packages: []string{"struct"},
-				StructRule: &StructRule[StructRuleResults]{
-					Params: StructRuleParameters{
-						RequireHeadlineComment: true,
-						RequireFieldComment:    true,
-					},
+				Checks: map[string]Checker{
+					"structs": NewChecker[StructRuleResults](StructRule[StructRuleResults]{
+						Params: StructRuleParameters{
+							RequireHeadlineComment: true,
+							RequireFieldComment:    true,
+						},
+					}),
 				},
 			},
 		}},
@@ -30,3 +37,172 @@ func TestStructRule(t *testing.T) {
 	analyzers, err := plugin.BuildAnalyzers()
 	analysistest.Run(t, testdata, analyzers[0], "struct")
 }
+
+func TestStructFields(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"structfields"},
+				Checks: map[string]Checker{
+					"structs": NewChecker[StructRuleResults](StructRule[StructRuleResults]{
+						Params: StructRuleParameters{
+							RequireFieldComment:         true,
+							RequireEmbeddedFieldComment: true,
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "structfields")
+}
+
+func TestStructTrailingComments(t *testing.T) {
+	fset := token.NewFileSet()
+	source := `package foo
+
+type Test struct {
+	Port int // listen port
+	Host string
+	io.Reader // source of the data
+}
+`
+	f, err := parser.ParseFile(fset, "foo.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+	spec := f.Decls[0].(*ast.GenDecl).Specs[0]
+
+	for _, allowTrailingComment := range []bool{false, true} {
+		var reported []string
+		pass := &analysis.Pass{Fset: fset, Report: func(d analysis.Diagnostic) {
+			reported = append(reported, d.Message)
+		}}
+		rule := StructRule[StructRuleResults]{Params: StructRuleParameters{
+			RequireFieldComment:         true,
+			RequireEmbeddedFieldComment: true,
+			AllowTrailingComment:        allowTrailingComment,
+		}}
+		rule.Apply(rule.Analyse(spec, pass, f), spec, pass)
+
+		expected := []string{"Field 'Host' is missing required comment"}
+		if !allowTrailingComment {
+			expected = []string{
+				"Field 'Port' is missing required comment",
+				"Field 'Host' is missing required comment",
+				"Embedded field 'Reader' is missing required comment",
+			}
+		}
+		if !slices.Equal(reported, expected) {
+			t.Errorf("Expected %v with allowTrailingComment=%t, but got %v", expected, allowTrailingComment, reported)
+		}
+	}
+}
+
+func TestStructRuleResultsOrder(t *testing.T) {
+	fset := token.NewFileSet()
+	source := `package foo
+
+type Test struct {
+	// Second is documented.
+	Second string
+	Nested struct {
+		// Inner is documented.
+		Inner string
+	}
+	First string
+	Third string
+}
+`
+	f, err := parser.ParseFile(fset, "foo.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+
+	rule := StructRule[StructRuleResults]{}
+	results := rule.Analyse(f.Decls[0].(*ast.GenDecl).Specs[0], &analysis.Pass{Fset: fset}, f)
+
+	expected := []struct {
+		name     string
+		line     int
+		comments int
+	}{
+		{name: "Second", line: 5, comments: 1},
+		{name: "Nested", line: 6, comments: 0},
+		{name: "Inner", line: 8, comments: 1},
+		{name: "First", line: 10, comments: 0},
+		{name: "Third", line: 11, comments: 0},
+	}
+	if len(results.FieldComments) != len(expected) {
+		t.Fatalf("Expected %d fields, but got %v", len(expected), results.FieldComments)
+	}
+	for i, field := range results.FieldComments {
+		line := fset.Position(field.Pos).Line
+		if field.Name != expected[i].name || line != expected[i].line || field.Comments != expected[i].comments {
+			t.Errorf("Expected field %d to be %v, but got %s in line %d with %d comments", i, expected[i], field.Name, line, field.Comments)
+		}
+	}
+}
+
+func TestStructTrivialComments(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"trivialstructs"},
+				Checks: map[string]Checker{
+					"structs": NewChecker[StructRuleResults](StructRule[StructRuleResults]{
+						Params: StructRuleParameters{
+							TrivialCommentThreshold:      0.5,
+							TrivialFieldCommentThreshold: 0.5,
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "trivialstructs")
+}
+
+func TestStructTrivialTrailingComments(t *testing.T) {
+	fset := token.NewFileSet()
+	source := `package foo
+
+type Test struct {
+	Email string // the email
+	// Port on which the server accepts connections.
+	Port int // port
+	A, B int // a and b
+}
+`
+	f, err := parser.ParseFile(fset, "foo.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+	spec := f.Decls[0].(*ast.GenDecl).Specs[0]
+
+	var reported []string
+	pass := &analysis.Pass{Fset: fset, Report: func(d analysis.Diagnostic) {
+		reported = append(reported, d.Message)
+	}}
+	rule := StructRule[StructRuleResults]{Params: StructRuleParameters{TrivialFieldCommentThreshold: 0.5}}
+	rule.Apply(rule.Analyse(spec, pass, f), spec, pass)
+
+	expected := []string{"Field 'Email' has a trivial comment. Similarity to field name: 100%, comment only restates words: email"}
+	if !slices.Equal(reported, expected) {
+		t.Errorf("Expected %v, but got %v", expected, reported)
+	}
+}
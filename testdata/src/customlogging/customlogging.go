@@ -0,0 +1,18 @@
+package customlogging
+
+import (
+	"catalog"
+	"log"
+)
+
+func customPackage() {
+	catalog.Info("started")
+}
+
+func otherMethod() { // want `Method 'otherMethod' has less than 1% logging density. Actual: 0%`
+	catalog.Print()
+}
+
+func presetNotSelected() { // want `Method 'presetNotSelected' has less than 1% logging density. Actual: 0%`
+	log.Print("started")
+}
@@ -0,0 +1,25 @@
+package deadcode
+
+import "fmt"
+
+// Sum adds up the values.
+func Sum(values []int) int { // want `Method 'Sum' has less than 50% comment density. Actual: 25%`
+	sum := 0
+	for _, value := range values {
+		sum += value
+	}
+	// result := 0 // want `Method 'Sum' contains 4 lines of commented-out code in lines 11-14`
+	// for i := range values {
+	// 	result += values[i]
+	// }
+	return sum
+}
+
+// Print prints the values.
+func Print(values []int) {
+	/* fmt.Println(len(values)) // want `Method 'Print' contains 1 lines of commented-out code in lines 20-20` */
+	for _, value := range values {
+		// print each value on its own line
+		fmt.Println(value)
+	}
+}
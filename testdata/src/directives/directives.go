@@ -0,0 +1,27 @@
+package directives
+
+//go:noinline
+func NoInline() { // want `Method 'NoInline' is missing required headline comment`
+}
+
+//nolint:errcheck
+func Unchecked() { // want `Method 'Unchecked' is missing required headline comment`
+}
+
+// TODO: document
+func Todo() { // want `Method 'Todo' is missing required headline comment`
+}
+
+// Documented does things.
+//
+//go:noinline
+func Documented() {
+}
+
+// Options of the command.
+//
+//go:generate stringer -type=Option
+type Option int
+
+//go:generate stringer -type=Mode
+type Mode int // want `Type 'Mode' is missing required headline comment`
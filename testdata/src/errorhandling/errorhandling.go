@@ -0,0 +1,100 @@
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+)
+
+func returned() error {
+	if err := os.Remove("file"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func wrapped() (string, error) {
+	_, err := os.Stat("file")
+	if err != nil {
+		return "", fmt.Errorf("stat file: %w", err)
+	}
+	return "file", nil
+}
+
+func logged() {
+	if err := os.Remove("file"); err != nil {
+		log.Printf("removing file: %v", err)
+	}
+}
+
+func ignored() {
+	if err := os.Remove("file"); err != nil { // want `Error 'err' is neither logged, wrapped nor returned`
+		fmt.Println("could not remove file")
+	}
+}
+
+func formattedWithoutWrapping() error {
+	var errs []error
+	if err := os.Remove("file"); err != nil { // want `Error 'err' is neither logged, wrapped nor returned`
+		errs = append(errs, fmt.Errorf("removing file: %v", os.ErrNotExist))
+	}
+	return errors.Join(errs...)
+}
+
+func joined() error {
+	var result error
+	if err := os.Remove("file"); err != nil {
+		result = errors.Join(result, err)
+	}
+	return result
+}
+
+func namedResult() (err error) {
+	defer func() {
+		if closeErr := os.Remove("file"); closeErr != nil { // want `Error 'closeErr' is neither logged, wrapped nor returned`
+			return
+		}
+	}()
+	if err = os.Remove("file"); err != nil {
+		return
+	}
+	return nil
+}
+
+func panicked() {
+	if err := os.Remove("file"); err != nil {
+		panic(err)
+	}
+}
+
+func doubleHandling() error {
+	if err := os.Remove("file"); err != nil { // want `Error 'err' is both logged and returned`
+		log.Printf("removing file: %v", err)
+		return fmt.Errorf("removing file: %w", err)
+	}
+	return nil
+}
+
+func loggedOtherError() error {
+	if err := os.Remove("file"); err != nil {
+		log.Printf("removing file failed")
+		return err
+	}
+	return nil
+}
+
+func loggedWithoutError() {
+	if err := os.Remove("file"); err != nil { // want `Error 'err' is neither logged, wrapped nor returned`
+		log.Printf("failed")
+	}
+}
+
+type notAnError struct{}
+
+func notChecked() {
+	var value *notAnError
+	if value != nil {
+		fmt.Println("value")
+	}
+}
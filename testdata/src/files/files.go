@@ -0,0 +1,5 @@
+package files
+
+func undocumented() bool { // want `Method 'undocumented' is missing required headline comment`
+	return true
+}
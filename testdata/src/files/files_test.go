@@ -0,0 +1,15 @@
+package files
+
+import "testing"
+
+func TestUndocumented(t *testing.T) {
+	undocumented()
+}
+
+func BenchmarkUndocumented(b *testing.B) {
+	undocumented()
+}
+
+func NewFixture() bool { // want `Method 'NewFixture' is missing required headline comment`
+	return true
+}
@@ -0,0 +1,5 @@
+package files
+
+func mocked() bool {
+	return true
+}
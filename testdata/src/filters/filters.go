@@ -0,0 +1,50 @@
+package filters
+
+func Exported() { // want `Method 'Exported' is missing required headline comment`
+}
+
+func unexported() {
+}
+
+func ExportedMock() {
+}
+
+type Service struct{}
+
+type service struct{}
+
+type ServiceHandler struct{}
+
+func (s *Service) Get() { // want `Method 'Get' is missing required headline comment`
+}
+
+func (s service) Get() {
+}
+
+func (s *ServiceHandler) Get() {
+}
+
+func (s *Service) get() {
+}
+
+type Reader interface { // want `Interface 'Reader' is missing required headline comment`
+	Read() // want `Method 'Read' is missing required comment`
+}
+
+type Empty interface {
+	Reader
+}
+
+type reader interface {
+	Read()
+}
+
+type Config struct { // want `Struct 'Config' is missing required headline comment`
+	Name string // want `Field 'Name' is missing required comment`
+}
+
+type Marker struct{}
+
+type config struct {
+	Name string
+}
@@ -0,0 +1,26 @@
+package fixes
+
+func GetValue() int { // want `Method 'GetValue' is missing required headline comment`
+	return 1
+}
+
+// Service is documented.
+type Service struct{}
+
+func (s Service) Run() { // want `Method 'Run' is missing required headline comment`
+}
+
+type Repository interface { // want `Interface 'Repository' is missing required headline comment`
+	Find() int // want `Method 'Find' is missing required comment`
+}
+
+type Config struct { // want `Struct 'Config' is missing required headline comment`
+	Port int // want `Field 'Port' is missing required comment`
+}
+
+type (
+	// Documented is documented.
+	Documented struct{}
+
+	Grouped struct{} // want `Struct 'Grouped' is missing required headline comment`
+)
@@ -140,7 +140,7 @@ func longMethodWithInlineComments() bool { // want `Method 'longMethodWithInline
 }
 
 // downloadArtifacts downloads the artifacts
-func downloadArtifacts() bool { // want `Method 'downloadArtifacts' has a trivial comment. Similarity to method name: 40%` `Method 'downloadArtifacts' has less than 10% logging density. Actual: 0%`
+func downloadArtifacts() bool { // want `Method 'downloadArtifacts' has a trivial comment. Similarity to method name: 100%, comment only restates words: download, artifacts` `Method 'downloadArtifacts' has less than 10% logging density. Actual: 0%`
 	// method has a trivial comment
 	s := "abc"
 	s += "1"
@@ -0,0 +1,44 @@
+package generics
+
+// Map applies f to each element of type T and returns the results of type U.
+func Map[T, U any](values []T, f func(T) U) []U {
+	return nil
+}
+
+// Filter returns the values matching the predicate.
+func Filter[T any](values []T, predicate func(T) bool) []T { // want `Method 'Filter' does not mention type parameter 'T' in its headline comment`
+	return nil
+}
+
+// Set contains unique values of type T.
+type Set[T comparable] struct {
+	values map[T]struct{}
+}
+
+// Pair contains two values.
+type Pair[K comparable, V any] struct { // want `Struct 'Pair' does not mention type parameter 'K' in its headline comment` `Struct 'Pair' does not mention type parameter 'V' in its headline comment`
+	Key   K
+	Value V
+}
+
+// Container stores values of type T.
+type Container[T any] interface {
+	Add(value T)
+}
+
+// Number is a numeric type.
+type Number interface {
+	// integers and floats can be summed up.
+	~int | ~float64
+}
+
+// Text is a string type.
+type Text interface {
+	~string | // want `Constraint '~string | ~\[\]byte' of interface 'Text' is missing required comment`
+		~[]byte
+}
+
+// Ordered is a type with an order.
+type Ordered interface {
+	~int | ~string // types supporting the < operator.
+}
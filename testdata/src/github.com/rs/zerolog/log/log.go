@@ -0,0 +1,7 @@
+package log
+
+import "github.com/rs/zerolog"
+
+var Logger = zerolog.Logger{}
+
+func Info() *zerolog.Event { return Logger.Info() }
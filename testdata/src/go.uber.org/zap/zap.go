@@ -0,0 +1,19 @@
+package zap
+
+type Logger struct{}
+
+func L() *Logger { return &Logger{} }
+
+func (l *Logger) Info(msg string, fields ...Field) {}
+
+func (l *Logger) Sugar() *SugaredLogger { return &SugaredLogger{} }
+
+type Field struct{}
+
+func String(key string, value string) Field { return Field{} }
+
+type SugaredLogger struct{}
+
+func (s *SugaredLogger) Infof(template string, args ...any) {}
+
+func (s *SugaredLogger) Infow(msg string, keysAndValues ...any) {}
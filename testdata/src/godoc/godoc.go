@@ -0,0 +1,43 @@
+package godoc
+
+// GetValue returns the value.
+func GetValue() string {
+	return ""
+}
+
+// getName returns the name.
+func GetName() string { // want `Doc comment of 'GetName' should start with 'GetName'`
+	return ""
+}
+
+// Returns the identifier.
+func GetID() string { // want `Doc comment of 'GetID' should start with 'GetID'`
+	return ""
+}
+
+// Close closes the connection
+func Close() { // want `Doc comment of 'Close' should be a full sentence`
+}
+
+// TODO: document
+func Open() { // want `Doc comment of 'Open' is a bare TODO`
+}
+
+// A Request is sent to the server.
+type Request struct {
+	// url of the request.
+	URL string // want `Doc comment of 'URL' should start with 'URL'`
+	// Body of the request.
+	Body []byte
+	// Headers and Trailers of the request.
+	Headers, Trailers map[string]string
+}
+
+// The Reader reads requests.
+type Reader interface {
+	// read reads a request.
+	Read() Request // want `Doc comment of 'Read' should start with 'Read'`
+}
+
+// status of a request.
+type Status int // want `Doc comment of 'Status' should start with 'Status'`
@@ -0,0 +1,39 @@
+package groups
+
+// The group comment does not document the specs of the group.
+type (
+	First struct { // want `Struct 'First' is missing required headline comment`
+		// Name is documented.
+		Name string
+	}
+
+	// Second is documented by its own comment.
+	Second interface {
+		// Get is documented.
+		Get() string
+	}
+
+	Third interface { // want `Interface 'Third' is missing required headline comment`
+		// Get is documented.
+		Get() string
+	}
+)
+
+// Single is documented by the comment of the declaration.
+type (
+	Single struct{}
+)
+
+// Plain is documented.
+type Plain struct{}
+
+func local() {
+	// Local is documented by the comment of the declaration.
+	type Local struct {
+		// Name is documented.
+		Name string
+	}
+
+	type Undocumented struct{} // want `Struct 'Undocumented' is missing required headline comment`
+	_, _ = Local{}, Undocumented{}
+}
@@ -2,13 +2,13 @@ package interfaces
 
 var i = "test"
 
-type TestWithoutComments interface { // want `Interface 'TestWithoutComments' is missing required headline comment` `Method 'Method' is missing required comment`
-	Method() bool
+type TestWithoutComments interface { // want `Interface 'TestWithoutComments' is missing required headline comment`
+	Method() bool // want `Method 'Method' is missing required comment`
 }
 
 // This has a sample comment
-type TestWithHeadlineComments interface { // want `Method 'Method' is missing required comment`
-	Method() bool
+type TestWithHeadlineComments interface {
+	Method() bool // want `Method 'Method' is missing required comment`
 }
 
 // This is a comment
@@ -16,3 +16,10 @@ type TestWithComments interface {
 	// Comment
 	Method() bool
 }
+
+// TestWithCallback has a method with a function parameter, which is not a method of the interface.
+type TestWithCallback interface {
+	// Subscribe registers the callback.
+	Subscribe(callback func(string))
+	Unsubscribe() // want `Method 'Unsubscribe' is missing required comment`
+}
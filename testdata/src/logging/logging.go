@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"catalog"
+	"context"
+	"log"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+	zerologlog "github.com/rs/zerolog/log"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+)
+
+type service struct {
+	logger *slog.Logger
+}
+
+func stdlib() {
+	log.Printf("started")
+}
+
+func slogFunction() {
+	slog.InfoContext(context.Background(), "started")
+}
+
+func (s *service) field() {
+	s.logger.Info("started")
+}
+
+func shortName(l *slog.Logger) {
+	l.Warn("started")
+}
+
+func zapGlobal() {
+	zap.L().Info("started")
+}
+
+func zapSugar(logger *zap.Logger) {
+	logger.Sugar().Infow("started", "id", 1)
+}
+
+func logrusEntry() {
+	logrus.WithField("id", 1).Warn("started")
+}
+
+func zerologEvent(logger zerolog.Logger) {
+	logger.Info().Str("id", "1").Msg("started")
+}
+
+func zerologGlobal() {
+	zerologlog.Info().Msg("started")
+}
+
+func notLogging() { // want `Method 'notLogging' has less than 1% logging density. Actual: 0%`
+	catalog.Print()
+}
+
+func levelWithoutMessage(logger zerolog.Logger) { // want `Method 'levelWithoutMessage' has less than 1% logging density. Actual: 0%`
+	logger.Info()
+}
+
+func unknownPackage() { // want `Method 'unknownPackage' has less than 1% logging density. Actual: 0%`
+	catalog.Info("started")
+}
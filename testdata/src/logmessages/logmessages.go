@@ -0,0 +1,49 @@
+package logmessages
+
+import (
+	"context"
+	"log"
+	"log/slog"
+
+	"go.uber.org/zap"
+	"go/ast"
+)
+
+const startedMessage = "service started"
+
+func constantMessages(ctx context.Context, logger *slog.Logger) {
+	slog.Info("service started", "port", 8080)
+	slog.InfoContext(ctx, startedMessage)
+	logger.Log(ctx, slog.LevelInfo, "service "+"started")
+	logger.Info("service started", slog.String("port", "8080"), "host", "localhost")
+}
+
+func variableMessage(name string) {
+	slog.Info("service " + name + " started") // want `Message of log call 'Info' is not a constant string`
+}
+
+func formatCall(port int) {
+	log.Printf("service started on port %d", port) // want `Format-style log call 'Printf' should use key-value arguments`
+}
+
+func keyValues(logger *zap.Logger) {
+	slog.Info("service started", "port")                    // want `Log call 'Info' has a key without value`
+	slog.Warn("service stopped", 42, "code")                // want `Key '42' of log call 'Warn' is not a string`
+	logger.Sugar().Infow("service started", "port", 8080)   // key-value pairs are fine
+	logger.Info("service started", zap.String("port", "1")) // fields are not key-value pairs
+}
+
+func casing() {
+	slog.Info("Service started")  // want `Log message 'Service started' should start with a lowercase letter`
+	slog.Info("service started.") // want `Log message 'service started.' should not end with punctuation`
+	slog.Info("404 not found")
+}
+
+func withoutMessage(err error) {
+	log.Print(err)                  // the values of Print are no message
+	log.Println("Service started.") // not checked as a message either
+}
+
+func unrelated(node ast.Node) {
+	_ = node.Pos()
+}
@@ -0,0 +1,7 @@
+//nolint:qawaylinter/requireHeadlineComment
+
+package nolint
+
+func fileLevel() bool { // want `Method 'fileLevel' has less than 10% logging density. Actual: 0%`
+	return true
+}
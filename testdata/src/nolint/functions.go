@@ -0,0 +1,43 @@
+package nolint
+
+import "log"
+
+func lineLevel() bool { //nolint:qawaylinter/minLoggingDensity // want `Method 'lineLevel' is missing required headline comment`
+	return true
+}
+
+// declarationLevel suppresses the logging density for the whole declaration.
+//
+//nolint:qawaylinter/functions/minLoggingDensity
+func declarationLevel() bool {
+	return true
+}
+
+//nolint:qawaylinter/functions
+func ruleKind() bool {
+	return true
+}
+
+//nolint:qawaylinter
+func allRules() bool {
+	return true
+}
+
+// otherLinter does not suppress anything as the directive is meant for another linter.
+//
+//nolint:errcheck
+func otherLinter() bool { // want `Method 'otherLinter' has less than 10% logging density. Actual: 0%`
+	return true
+}
+
+// unrelatedRuleKind does not suppress the logging density as the rule kind does not match.
+//
+//nolint:qawaylinter/interfaces
+func unrelatedRuleKind() bool { // want `Method 'unrelatedRuleKind' has less than 10% logging density. Actual: 0%`
+	return true
+}
+
+// withLogging has a perfect logging density.
+func withLogging() {
+	log.Printf("Hello World")
+}
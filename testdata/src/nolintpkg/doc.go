@@ -0,0 +1,4 @@
+// Package nolintpkg suppresses the logging density for all files of the package.
+//
+//nolint:qawaylinter/minLoggingDensity
+package nolintpkg
@@ -0,0 +1,14 @@
+package nolintpkg
+
+func packageLevel() bool { // want `Method 'packageLevel' is missing required headline comment`
+	return true
+}
+
+// belowPackageDoc is declared below the last line of doc.go, so only the package-level directive covers it.
+func belowPackageDoc(values []int) int {
+	sum := 0
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
@@ -0,0 +1,2 @@
+// Package packagedoc is documented in the wrong file. // want `Package comment of 'packagedoc' must be placed in doc.go`
+package packagedoc
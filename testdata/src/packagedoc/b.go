@@ -0,0 +1,2 @@
+// This comment conflicts with the one in a.go. // want `Package comment of 'packagedoc' must be placed in doc.go` `Package 'packagedoc' has conflicting package comments in a.go and b.go` `Package comment of 'packagedoc' should start with 'Package packagedoc'`
+package packagedoc
@@ -0,0 +1 @@
+package packagenodoc // want `Package 'packagenodoc' is missing required package comment`
@@ -0,0 +1 @@
+package packagenodoc_test
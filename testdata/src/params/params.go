@@ -0,0 +1,31 @@
+package params
+
+import "context"
+
+// Copy copies n bytes from src to dst.
+func Copy(dst []byte, src []byte, n int) {
+}
+
+// Send sends the msg.
+func Send(ctx context.Context, msg string, retries int) { // want `Method 'Send' does not mention parameter 'retries' in its headline comment`
+}
+
+// Get returns the value.
+func Get(key string) string {
+	return ""
+}
+
+// Load reads the configuration from path.
+func Load(path string) error { // want `Method 'Load' returns an error, but its headline comment does not describe when`
+	return nil
+}
+
+// Save writes the configuration to path and fails if the file is read-only.
+func Save(path string) error {
+	return nil
+}
+
+// Split splits the text.
+func Split(text string) (head string, tail string) { // want `Method 'Split' does not mention named result 'head' in its headline comment` `Method 'Split' does not mention named result 'tail' in its headline comment`
+	return "", ""
+}
@@ -0,0 +1,8 @@
+package registry
+
+func empty() { // want `empty function: empty`
+}
+
+func notEmpty() bool {
+	return true
+}
@@ -1,11 +1,18 @@
 package _struct
 
 // Test is a struct
-type Test struct { // want `Field 'MissingComment' is missing required comment`
-	MissingComment string
+type Test struct {
+	MissingComment string // want `Field 'MissingComment' is missing required comment`
 }
 
 type TestWithoutComment struct { // want `Struct 'TestWithoutComment' is missing required headline comment`
 	// Comment
 	MethodWithComment string
 }
+
+// TestOrder reports its fields in order of their position.
+type TestOrder struct {
+	First  string // want `Field 'First' is missing required comment`
+	Second string // want `Field 'Second' is missing required comment`
+	Third  string // want `Field 'Third' is missing required comment`
+}
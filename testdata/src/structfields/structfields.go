@@ -0,0 +1,41 @@
+package structfields
+
+import (
+	"io"
+	"time"
+)
+
+// List is a generic list.
+type List[T any] struct {
+	// Items of the list.
+	Items []T
+}
+
+// Fields contains fields of all shapes.
+type Fields struct {
+	Pointer  *List[int]             // want `Field 'Pointer' is missing required comment`
+	Duration time.Duration          // want `Field 'Duration' is missing required comment`
+	Slice    []string               // want `Field 'Slice' is missing required comment`
+	Map      map[string]int         // want `Field 'Map' is missing required comment`
+	Callback func()                 // want `Field 'Callback' is missing required comment`
+	Channel  chan struct{}          // want `Field 'Channel' is missing required comment`
+	Inline   struct{ Value string } // want `Field 'Inline' is missing required comment` `Field 'Value' is missing required comment`
+	// Documented is not reported.
+	Documented *time.Location
+}
+
+// MultiName reports each name of a field.
+type MultiName struct {
+	A, B int // want `Field 'A' is missing required comment` `Field 'B' is missing required comment`
+	// C and D are documented.
+	C, D int
+}
+
+// Embedded reports embedded fields.
+type Embedded struct {
+	io.Reader  // want `Embedded field 'Reader' is missing required comment`
+	*List[int] // want `Embedded field 'List' is missing required comment`
+	time.Time  // want `Embedded field 'Time' is missing required comment`
+	// Writer is documented.
+	io.Writer
+}
@@ -0,0 +1,16 @@
+package trivialinterfaces
+
+// Reader is a reader.
+type Reader interface { // want `Interface 'Reader' has a trivial comment. Similarity to interface name: 100%, comment only restates words: reader`
+	// Read reads.
+	Read() string // want `Method 'Read' has a trivial comment. Similarity to method name: 100%, comment only restates words: read`
+	// Close releases the underlying connection so that it can be reused by the pool.
+	Close() error
+}
+
+// Repository loads and stores users in the database.
+type Repository interface {
+	// FindUser finds the user.
+	FindUser(id string) string // want `Method 'FindUser' has a trivial comment. Similarity to method name: 100%, comment only restates words: find, user`
+	Save(user string) error
+}
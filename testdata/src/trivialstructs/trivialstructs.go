@@ -0,0 +1,18 @@
+package trivialstructs
+
+// User is a user.
+type User struct { // want `Struct 'User' has a trivial comment. Similarity to struct name: 100%, comment only restates words: user`
+	// Name is the name.
+	Name string // want `Field 'Name' has a trivial comment. Similarity to field name: 100%, comment only restates words: name`
+	// Age in full years, used to check the minimum age for registration.
+	Age int
+	// First and last name.
+	First, Last string
+}
+
+// Server accepts connections and dispatches requests to the registered handlers.
+type Server struct {
+	// Handlers are the handlers.
+	Handlers []string // want `Field 'Handlers' has a trivial comment. Similarity to field name: 100%, comment only restates words: handlers`
+	Port     int
+}
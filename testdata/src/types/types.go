@@ -0,0 +1,32 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Status is the state of a job, e.g. pending or done.
+type Status int
+
+type Handler func(ctx context.Context) error // want `Type 'Handler' is missing required headline comment`
+
+type IDs []string // want `Type 'IDs' is missing required headline comment`
+
+type Timeout = time.Duration // want `Type 'Timeout' is missing required headline comment`
+
+// Duration is a duration.
+type Duration time.Duration // want `Type 'Duration' has a trivial comment. Similarity to type name: 100%, comment only restates words: duration`
+
+// Lookup maps names to identifiers.
+type Lookup map[string]int
+
+type Queue chan int
+
+type Values [4]int
+
+// Interfaces and structs are checked by their own rules.
+type (
+	Reader interface{}
+	Config struct{}
+	Alias  = struct{}
+)
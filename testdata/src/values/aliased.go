@@ -0,0 +1,12 @@
+package values
+
+import stderrors "errors"
+
+// ErrAliased is created with an aliased import of the errors package.
+var ErrAliased = stderrors.New("aliased") // want `Sentinel error 'ErrAliased' does not document when it is returned`
+
+// ErrReturnable is a returnable error.
+var ErrReturnable = stderrors.New("returnable") // want `Sentinel error 'ErrReturnable' does not document when it is returned`
+
+// ErrTimeout is returned if the job takes too long.
+var ErrTimeout = stderrors.New("timeout")
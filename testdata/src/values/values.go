@@ -0,0 +1,50 @@
+package values
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Documented is a documented constant.
+const Documented = 1
+
+const Undocumented = 2 // want `Value 'Undocumented' is missing required comment`
+
+const unexported = 3
+
+var (
+	// Timeout is the default timeout.
+	Timeout = 10
+	Retries = 3    // want `Value 'Retries' is missing required comment`
+	A, B    = 1, 2 // want `Value 'A' is missing required comment` `Value 'B' is missing required comment`
+)
+
+// Status is the status of a job.
+type Status int
+
+// Statuses of a job.
+const (
+	Pending Status = iota
+	Running
+	Done
+)
+
+// The group comment only documents enums.
+const (
+	First  = 1 // want `Value 'First' is missing required comment`
+	Second = 2 // want `Value 'Second' is missing required comment`
+)
+
+// ErrNotFound is returned if the job does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrInvalid means that the job is invalid.
+var ErrInvalid = fmt.Errorf("invalid") // want `Sentinel error 'ErrInvalid' does not document when it is returned`
+
+// ErrorCount is not an error.
+var ErrorCount = 0
+
+func local() int {
+	const Local = 1
+	return Local
+}
@@ -0,0 +1,164 @@
+package qawaylinter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"golang.org/x/tools/go/analysis"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterJSONRule[TypeRuleResults, TypeRule[TypeRuleResults]]("types")
+}
+
+// typeKinds are the kinds of types that can be used in the `kinds` filter of the types rule.
+var typeKinds = []string{"alias", "array", "chan", "func", "map", "named", "pointer", "slice"}
+
+type TypeFilters struct {
+	NameFilters
+	// Kinds restricts the rule to types of the given kinds. The kind is derived from the type the declaration refers to:
+	// `named` for types such as `int` or `time.Duration`, `func`, `slice`, `array`, `map`, `chan`, `pointer`.
+	// Aliases such as `type A = B` have the kind `alias`.
+	Kinds []string `json:"kinds"`
+}
+
+// Validate ensures that only known kinds are configured.
+func (f TypeFilters) Validate() error {
+	for i, kind := range f.Kinds {
+		if !slices.Contains(typeKinds, kind) {
+			return &ConfigError{
+				Path: "kinds[" + strconv.Itoa(i) + "]",
+				Err:  fmt.Errorf("unknown kind %q, expected one of %s", kind, strings.Join(typeKinds, ", ")),
+			}
+		}
+	}
+	return f.NameFilters.Validate()
+}
+
+type TypeRuleParameters struct {
+	// RequireHeadlineComment determines if a comment must be placed on top of the type.
+	RequireHeadlineComment bool `json:"requireHeadlineComment"`
+	// TrivialCommentThreshold determines the similarity between the type name and its comment above which the
+	// comment is reported as trivial.
+	TrivialCommentThreshold float64 `json:"trivialCommentThreshold" minimum:"0" maximum:"1"`
+	// SimilarityMetric determines how the similarity for TrivialCommentThreshold is measured:
+	// `levenshtein` (default), `jaccard` or `jaroWinkler`.
+	SimilarityMetric string `json:"similarityMetric"`
+	// RequireGodocConvention determines if the headline comment must follow the Go doc comment conventions: it must
+	// start with the name of the type (optionally preceded by `A`, `An` or `The`), its first paragraph must be a full
+	// sentence and it must not be a bare TODO.
+	RequireGodocConvention bool `json:"requireGodocConvention"`
+	// IgnoreCommentPrefixes are prefixes of comment lines that are not counted as documentation, e.g. `TODO`.
+	// Directives, blank lines and license headers are never counted.
+	IgnoreCommentPrefixes []string `json:"ignoreCommentPrefixes"`
+	// CommentTemplate is the text/template of the doc comment that is suggested as fix for a missing headline comment.
+	// The template receives CommentTemplateData. Defaults to `{{.Name}} ...`.
+	CommentTemplate string `json:"commentTemplate"`
+}
+
+// Validate ensures that the comment template can be parsed.
+func (p TypeRuleParameters) Validate() error {
+	if _, err := parseCommentTemplate(p.CommentTemplate); err != nil {
+		return &ConfigError{Path: "commentTemplate", Err: err}
+	}
+	if err := validateSimilarityMetric(p.SimilarityMetric); err != nil {
+		return &ConfigError{Path: "similarityMetric", Err: err}
+	}
+	return nil
+}
+
+type TypeRuleResults struct {
+	// Number of lines of comments in the headline of the type.
+	HeadlineComments int
+	// Position of the declaration, which is where a missing headline comment is inserted.
+	HeadlinePos token.Pos
+	// Indicates the similarity between the type name and the headline comments.
+	CommentSimilarity Similarity
+	// Headline comment of the type.
+	HeadlineDoc *ast.CommentGroup
+}
+
+// TypeRule checks all type declarations that are neither interfaces nor structs, which are checked by
+// InterfaceRule and StructRule.
+type TypeRule[ResultType TypeRuleResults] struct {
+	Filters TypeFilters        `json:"filters"`
+	Params  TypeRuleParameters `json:"params"`
+}
+
+func (t TypeRule[ResultType]) IsApplicable(node ast.Node, _ *analysis.Pass, _ *ast.File) bool {
+	spec, ok := node.(*ast.TypeSpec)
+	if !ok || spec.Type == nil {
+		return false
+	}
+	kind := typeKind(spec)
+	if kind == "" {
+		return false
+	}
+
+	return t.Filters.matchesName(spec.Name.Name) && (len(t.Filters.Kinds) == 0 || slices.Contains(t.Filters.Kinds, kind))
+}
+
+func (t TypeRule[ResultType]) Analyse(node ast.Node, pass *analysis.Pass, file *ast.File) *TypeRuleResults {
+	typespec, ok := node.(*ast.TypeSpec)
+	if !ok {
+		return nil
+	}
+
+	doc, headlinePos := typeSpecDoc(typespec, file)
+	return &TypeRuleResults{
+		HeadlineComments:  countCommentLines(doc, t.Params.IgnoreCommentPrefixes),
+		HeadlinePos:       headlinePos,
+		CommentSimilarity: CommentSimilarity(typespec.Name.Name, doc.Text(), t.Params.SimilarityMetric),
+		HeadlineDoc:       doc,
+	}
+}
+
+func (t TypeRule[ResultType]) Apply(analysis *TypeRuleResults, node ast.Node, pass *analysis.Pass) {
+	if analysis == nil {
+		return
+	}
+	name := node.(*ast.TypeSpec).Name.Name
+	if analysis.HeadlineComments == 0 && t.Params.RequireHeadlineComment {
+		fixes := suggestDocComment(pass, analysis.HeadlinePos, t.Params.CommentTemplate, CommentTemplateData{Name: name, Kind: "type"})
+		reportWithFixesf(pass, node.Pos(), "types/requireHeadlineComment", fixes, "Type '%s' is missing required headline comment", name)
+	}
+	if analysis.HeadlineComments > 0 && t.Params.RequireGodocConvention {
+		reportGodocIssues(pass, node.Pos(), "types/requireGodocConvention", analysis.HeadlineDoc, name, true)
+	}
+	if t.Params.TrivialCommentThreshold > 0 && analysis.HeadlineComments > 0 && analysis.CommentSimilarity.Score > t.Params.TrivialCommentThreshold {
+		reportf(pass, node.Pos(), "types/trivialCommentThreshold", "Type '%s' has a trivial comment. Similarity to type name: %.0f%%%s", name, analysis.CommentSimilarity.Score*100, explanationSuffix(analysis.CommentSimilarity))
+	}
+}
+
+// typeKind returns the kind of a type declaration as used in the `kinds` filter.
+// An empty string is returned for interfaces and structs, including aliases of them.
+func typeKind(spec *ast.TypeSpec) string {
+	switch spec.Type.(type) {
+	case *ast.InterfaceType, *ast.StructType:
+		return ""
+	}
+	if spec.Assign.IsValid() {
+		return "alias"
+	}
+	switch t := spec.Type.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr, *ast.ParenExpr:
+		return "named"
+	case *ast.FuncType:
+		return "func"
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "slice"
+		}
+		return "array"
+	case *ast.MapType:
+		return "map"
+	case *ast.ChanType:
+		return "chan"
+	case *ast.StarExpr:
+		return "pointer"
+	}
+	return ""
+}
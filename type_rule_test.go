@@ -0,0 +1,71 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTypeRule(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"types"},
+				Checks: map[string]Checker{
+					"types": NewChecker[TypeRuleResults](TypeRule[TypeRuleResults]{
+						Filters: TypeFilters{Kinds: []string{"named", "func", "slice", "alias"}},
+						Params: TypeRuleParameters{
+							RequireHeadlineComment:  true,
+							TrivialCommentThreshold: 0.3,
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "types")
+}
+
+func TestTypeKind(t *testing.T) {
+	source := `package foo
+
+type (
+	Named    int
+	Selector time.Duration
+	Generic  List[int]
+	Func     func() error
+	Slice    []string
+	Array    [2]string
+	Map      map[string]int
+	Chan     chan int
+	Pointer  *int
+	Alias    = int
+	Iface    interface{}
+	Struct   struct{}
+	AliasStr = struct{}
+)
+`
+	f, err := parser.ParseFile(token.NewFileSet(), "foo.go", source, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+
+	expected := []string{"named", "named", "named", "func", "slice", "array", "map", "chan", "pointer", "alias", "", "", ""}
+	for i, spec := range f.Decls[0].(*ast.GenDecl).Specs {
+		typeSpec := spec.(*ast.TypeSpec)
+		if kind := typeKind(typeSpec); kind != expected[i] {
+			t.Errorf("typeKind(%s) = %q; want %q", typeSpec.Name.Name, kind, expected[i])
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	RegisterJSONRule[ValueRuleResults, ValueRule[ValueRuleResults]]("values")
+}
+
+// returnPattern matches a doc comment that documents when a sentinel error is returned, e.g. `is returned if`.
+var returnPattern = regexp.MustCompile(`(?i)\breturn(s|ed|ing)?\b`)
+
+type ValueRuleParameters struct {
+	// RequireComment determines if a comment must be placed on top of each exported constant and variable.
+	// The comment of a declaration group is accepted for the values of an enum, i.e. a const group using iota.
+	RequireComment bool `json:"requireComment"`
+	// RequireEnumValueComment determines if each value of an enum must have its own comment in addition to the
+	// comment of the group.
+	RequireEnumValueComment bool `json:"requireEnumValueComment"`
+	// AllowTrailingComment determines if a comment at the end of the line of a value, e.g. `StatusOK = iota // ok`,
+	// satisfies RequireComment.
+	AllowTrailingComment bool `json:"allowTrailingComment"`
+	// RequireSentinelErrorDoc determines if the comment of exported sentinel errors such as
+	// `var ErrNotFound = errors.New("not found")` must describe when the error is returned.
+	RequireSentinelErrorDoc bool `json:"requireSentinelErrorDoc"`
+	// IgnoreCommentPrefixes are prefixes of comment lines that are not counted as documentation, e.g. `TODO`.
+	// Directives, blank lines and license headers are never counted.
+	IgnoreCommentPrefixes []string `json:"ignoreCommentPrefixes"`
+	// CommentTemplate is the text/template of the doc comment that is suggested as fix for missing comments.
+	// The template receives CommentTemplateData. Defaults to `{{.Name}} ...`.
+	CommentTemplate string `json:"commentTemplate"`
+}
+
+// Validate ensures that the comment template can be parsed.
+func (p ValueRuleParameters) Validate() error {
+	if _, err := parseCommentTemplate(p.CommentTemplate); err != nil {
+		return &ConfigError{Path: "commentTemplate", Err: err}
+	}
+	return nil
+}
+
+// ValueComments are the comments of a single constant or variable.
+type ValueComments struct {
+	MemberComments
+	// Position where a missing comment is inserted.
+	DocPos token.Pos
+	// Text of the comment on top of the value.
+	Doc string
+	// Indicates that the value is a sentinel error created with errors.New or fmt.Errorf.
+	SentinelError bool
+}
+
+type ValueRuleResults struct {
+	// Number of lines of comments on top of a parenthesized declaration group.
+	GroupComments int
+	// Indicates that the declaration is an enum, i.e. a const group using iota.
+	Enum bool
+	// Comments of each value, ordered by their position in the source.
+	Values []ValueComments
+}
+
+type ValueRule[ResultType ValueRuleResults] struct {
+	Params ValueRuleParameters `json:"params"`
+}
+
+func (v ValueRule[ResultType]) IsApplicable(node ast.Node, _ *analysis.Pass, file *ast.File) bool {
+	genDecl, ok := node.(*ast.GenDecl)
+	if !ok || (genDecl.Tok != token.CONST && genDecl.Tok != token.VAR) {
+		return false
+	}
+
+	// local constants and variables are not part of the documentation.
+	return topLevelDecl(file, genDecl) == ast.Decl(genDecl)
+}
+
+func (v ValueRule[ResultType]) Analyse(node ast.Node, pass *analysis.Pass, _ *ast.File) *ValueRuleResults {
+	genDecl, ok := node.(*ast.GenDecl)
+	if !ok {
+		return nil
+	}
+
+	results := &ValueRuleResults{Enum: genDecl.Tok == token.CONST && usesIota(genDecl)}
+	if len(genDecl.Specs) > 1 {
+		results.GroupComments = countCommentLines(genDecl.Doc, v.Params.IgnoreCommentPrefixes)
+	}
+
+	for _, spec := range genDecl.Specs {
+		valueSpec := spec.(*ast.ValueSpec)
+		doc, docPos := valueSpec.Doc, valueSpec.Pos()
+		if len(genDecl.Specs) == 1 && doc == nil {
+			doc, docPos = genDecl.Doc, genDecl.Pos()
+		}
+
+		for i, name := range valueSpec.Names {
+			var value ast.Expr
+			if len(valueSpec.Values) == len(valueSpec.Names) {
+				value = valueSpec.Values[i]
+			}
+			results.Values = append(results.Values, ValueComments{
+				MemberComments: MemberComments{
+					Name:             name.Name,
+					Pos:              name.Pos(),
+					Comments:         countCommentLines(doc, v.Params.IgnoreCommentPrefixes),
+					TrailingComments: countCommentLines(valueSpec.Comment, v.Params.IgnoreCommentPrefixes),
+				},
+				DocPos:        docPos,
+				Doc:           doc.Text(),
+				SentinelError: genDecl.Tok == token.VAR && isSentinelError(name.Name, value, pass.TypesInfo),
+			})
+		}
+	}
+	return results
+}
+
+func (v ValueRule[ResultType]) Apply(analysis *ValueRuleResults, node ast.Node, pass *analysis.Pass) {
+	if analysis == nil {
+		return
+	}
+	kind := "variable"
+	if node.(*ast.GenDecl).Tok == token.CONST {
+		kind = "constant"
+	}
+
+	for _, value := range analysis.Values {
+		if !ast.IsExported(value.Name) {
+			continue
+		}
+
+		documented := value.Comments > 0 || (v.Params.AllowTrailingComment && value.TrailingComments > 0)
+		documentedByGroup := analysis.Enum && analysis.GroupComments > 0 && !v.Params.RequireEnumValueComment
+		if !documented && !documentedByGroup && v.Params.RequireComment {
+			fixes := suggestDocComment(pass, value.DocPos, v.Params.CommentTemplate, CommentTemplateData{Name: value.Name, Kind: kind})
+			reportWithFixesf(pass, value.Pos, "values/requireComment", fixes, "Value '%s' is missing required comment", value.Name)
+			continue
+		}
+		if value.SentinelError && v.Params.RequireSentinelErrorDoc && !returnPattern.MatchString(value.Doc) {
+			reportf(pass, value.Pos, "values/requireSentinelErrorDoc", "Sentinel error '%s' does not document when it is returned", value.Name)
+		}
+	}
+}
+
+// usesIota determines if any value of the declaration refers to iota.
+func usesIota(genDecl *ast.GenDecl) bool {
+	found := false
+	ast.Inspect(genDecl, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "iota" {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isSentinelError determines if a variable is a sentinel error, i.e. it is named `ErrXxx` and created by
+// errors.New or fmt.Errorf. With type information, the callee is resolved, which covers aliased imports and ignores
+// identifiers that shadow the packages.
+func isSentinelError(name string, value ast.Expr, info *types.Info) bool {
+	if !strings.HasPrefix(name, "Err") {
+		return false
+	}
+	call, ok := value.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	pkg, function := calledFunction(call, info)
+	return (pkg == "errors" && function == "New") || (pkg == "fmt" && function == "Errorf")
+}
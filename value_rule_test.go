@@ -0,0 +1,123 @@
+package qawaylinter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestValueRule(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	testdata := filepath.Join(wd, "testdata")
+	plugin := AnalyzerPlugin{Settings: Settings{
+		Targets: []Rules{
+			{
+				Packages: []string{"values"},
+				Checks: map[string]Checker{
+					"values": NewChecker[ValueRuleResults](ValueRule[ValueRuleResults]{
+						Params: ValueRuleParameters{
+							RequireComment:          true,
+							RequireSentinelErrorDoc: true,
+						},
+					}),
+				},
+			},
+		}},
+	}
+	analyzers, err := plugin.BuildAnalyzers()
+	analysistest.Run(t, testdata, analyzers[0], "values")
+}
+
+func TestValueRuleEnumValueComments(t *testing.T) {
+	fset := token.NewFileSet()
+	source := `package foo
+
+// Statuses of a job.
+const (
+	// Pending jobs have not been started yet.
+	Pending = iota
+	Running // job is running
+	Done
+)
+`
+	f, err := parser.ParseFile(fset, "foo.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		params   ValueRuleParameters
+		expected []string
+	}{
+		{
+			name:     "Group comment",
+			params:   ValueRuleParameters{RequireComment: true},
+			expected: nil,
+		},
+		{
+			name:     "Value comments",
+			params:   ValueRuleParameters{RequireComment: true, RequireEnumValueComment: true},
+			expected: []string{"Value 'Running' is missing required comment", "Value 'Done' is missing required comment"},
+		},
+		{
+			name:     "Trailing comments",
+			params:   ValueRuleParameters{RequireComment: true, RequireEnumValueComment: true, AllowTrailingComment: true},
+			expected: []string{"Value 'Done' is missing required comment"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []string
+			pass := &analysis.Pass{Fset: fset, Report: func(d analysis.Diagnostic) {
+				reported = append(reported, d.Message)
+			}}
+			NewChecker[ValueRuleResults](ValueRule[ValueRuleResults]{Params: tt.params}).Check(f.Decls[0], pass, f)
+
+			if !slices.Equal(reported, tt.expected) {
+				t.Errorf("Expected %v, but got %v", tt.expected, reported)
+			}
+		})
+	}
+}
+
+func TestSentinelErrorShadowedPackage(t *testing.T) {
+	fset := token.NewFileSet()
+	source := `package foo
+
+type factory struct{}
+
+func (factory) New(message string) string { return message }
+
+var errors = factory{}
+
+var ErrMessage = errors.New("message")
+`
+	f, err := parser.ParseFile(fset, "foo.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %s", err)
+	}
+	info := &types.Info{Types: map[ast.Expr]types.TypeAndValue{}, Uses: map[*ast.Ident]types.Object{}, Defs: map[*ast.Ident]types.Object{}}
+	if _, err := (&types.Config{}).Check("foo", fset, []*ast.File{f}, info); err != nil {
+		t.Fatalf("Failed to check source: %s", err)
+	}
+
+	spec := f.Decls[3].(*ast.GenDecl).Specs[0].(*ast.ValueSpec)
+	if isSentinelError(spec.Names[0].Name, spec.Values[0], info) {
+		t.Errorf("Expected 'ErrMessage' created by a shadowing identifier not to be a sentinel error")
+	}
+	if !isSentinelError(spec.Names[0].Name, spec.Values[0], nil) {
+		t.Errorf("Expected 'ErrMessage' to be a sentinel error without type information")
+	}
+}